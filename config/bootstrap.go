@@ -0,0 +1,104 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nil-go/konf"
+
+	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/log"
+)
+
+// Bootstrap assembles the konf.Config from the sources declared by the given Option(s)
+// in a start gate of the nilgo.Runner, so the main runs start after the configuration has been loaded.
+// It sets the config as the default konf.Config (see [SetDefault]), and watches the changes of sources as a pre run.
+// It also re-reads all sources when the Runner reloads (see [Reload]),
+// e.g. on the signals provided by nilgo.WithReloadSignals.
+// The config is released once the Run ends (see [Release]).
+//
+// The log levels in config `log.levels` are applied to log.Default in place, e.g.
+//
+//	log:
+//	  levels:
+//	    - name: github.com/nil-go/nilgo
+//	      level: DEBUG
+//
+// The Runner fails to start with the error if any source fails to load.
+func Bootstrap(opts ...Option) nilgo.Option {
+	var (
+		cfg    atomic.Pointer[konf.Config]
+		once   sync.Once
+		loaded = make(chan struct{})
+		levels = applyLogLevels(log.Default())
+	)
+
+	return nilgo.WithOptions(
+		nilgo.WithStartGate(func(context.Context) error {
+			c, err := New(opts...)
+			if err != nil {
+				return err
+			}
+			var values []logLevel
+			if err := c.Unmarshal(levelsPath, &values); err != nil {
+				return fmt.Errorf("load config of log levels: %w", err)
+			}
+			if err := levels(values); err != nil {
+				return err
+			}
+			SetDefault(c)
+			if old := cfg.Swap(c); old != nil {
+				Release(old) // It's replaced by the config of the new Run.
+			}
+			once.Do(func() { close(loaded) })
+			slog.Info("Config has been loaded.")
+
+			return nil
+		}),
+		nilgo.WithPreRun(func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-loaded:
+			}
+			defer OnChange(levelsPath, levels)()
+			c := cfg.Load()
+			defer Release(c)
+
+			return c.Watch(ctx) //nolint:wrapcheck
+		}),
+		nilgo.WithReloadHook(func(context.Context) error {
+			if c := cfg.Load(); c != nil {
+				return Reload(c)
+			}
+
+			return nil
+		}),
+	)
+}
+
+// RestartOnChange restarts the named run gracefully once the configuration under any of the given paths
+// changes, for the run which can not reconfigure in place. The change is logged with the before/after diff
+// (see [OnChange]). It requires the configuration is watched, e.g. by [Bootstrap].
+//
+// The run must be able to execute again, e.g. http.Run, or grpc.RunNew which creates the server for each execution.
+func RestartOnChange(paths ...string) nilgo.RunOption {
+	triggers := make([]func(func()) func(), 0, len(paths))
+	for _, path := range paths {
+		triggers = append(triggers, func(restart func()) func() {
+			return OnChange(path, func(any) error {
+				restart()
+
+				return nil
+			})
+		})
+	}
+
+	return nilgo.RestartOn(triggers...)
+}
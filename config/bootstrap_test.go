@@ -0,0 +1,153 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package config_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nil-go/konf"
+
+	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/config"
+	"github.com/nil-go/nilgo/config/internal/assert"
+	"github.com/nil-go/nilgo/log"
+)
+
+//nolint:paralleltest // It changes the default config.
+func TestBootstrap(t *testing.T) {
+	fsys := fstest.MapFS{"config.yaml": {Data: []byte("app:\n  name: nilgo\n")}}
+	runner := nilgo.New(config.Bootstrap(config.WithFS(fsys, "config.yaml")))
+	var (
+		name   string
+		values []config.Value
+	)
+	assert.NoError(t, runner.Run(context.Background(), func(context.Context) error {
+		name = konf.Get[string]("app.name")
+		values, _ = config.Values(nil, "app")
+
+		return nil
+	}))
+	assert.Equal(t, "nilgo", name)
+	assert.Equal(t, []config.Value{{Path: "app.name", Value: "nilgo", Loader: "fs:///config.yaml"}}, values)
+	// The config is released once the Run ends.
+	_, err := config.Values(nil, "app")
+	assert.Equal(t, config.ErrNotTracked, err)
+	config.SetDefault(konf.New())
+
+	runner = nilgo.New(config.Bootstrap(config.WithFS(fsys, "not_found.yaml")))
+	err = runner.Run(context.Background(), func(context.Context) error { return nil })
+	var runnerErr *nilgo.Error
+	assert.Equal(t, true, errors.As(err, &runnerErr))
+	runErr := runnerErr.Errors[0]
+	assert.Equal(t, nilgo.PhaseStartGate, runErr.Phase)
+	assert.EqualError(t, runErr.Err,
+		"load config from fs:///not_found.yaml: load configuration: read file: open not_found.yaml: file does not exist")
+}
+
+//nolint:paralleltest // It changes the default config and sends signal.
+func TestBootstrap_reload(t *testing.T) {
+	loader := &versionLoader{}
+	loader.version.Store(1)
+	versions := make(chan int, 1)
+	runner := nilgo.New(config.Bootstrap(config.WithLoader(loader)), nilgo.WithReloadSignals(syscall.SIGHUP))
+	assert.NoError(t, runner.Run(context.Background(), func(context.Context) error {
+		versions <- konf.Get[int]("app.version")
+		defer config.OnChange("app.version", func(version int) error {
+			versions <- version
+
+			return nil
+		})()
+
+		loader.version.Store(2)
+		if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+			return err
+		}
+		assert.Equal(t, 1, <-versions)
+		assert.Equal(t, 2, <-versions)
+
+		return nil
+	}))
+	config.SetDefault(konf.New())
+}
+
+type versionLoader struct {
+	version atomic.Int64
+}
+
+func (v *versionLoader) Load() (map[string]any, error) {
+	return map[string]any{"app": map[string]any{"version": v.version.Load()}}, nil
+}
+
+//nolint:paralleltest // It changes the default config and log levels.
+func TestBootstrap_logLevels(t *testing.T) {
+	levels := func(level string) map[string]any {
+		return map[string]any{"log": map[string]any{"levels": []any{
+			map[string]any{"name": "github.com/nil-go/nilgo", "level": level},
+		}}}
+	}
+	loader := &watchLoader{values: levels("DEBUG"), onChange: make(chan func(map[string]any), 1)}
+	runner := nilgo.New(config.Bootstrap(config.WithLoader(loader)))
+	defer log.Reset("github.com/nil-go/nilgo")
+	defer config.SetDefault(konf.New())
+
+	assert.NoError(t, runner.Run(context.Background(), func(context.Context) error {
+		level, _ := log.Default().Level("github.com/nil-go/nilgo/config")
+		assert.Equal(t, slog.LevelDebug, level)
+
+		// The subscription of Bootstrap is ahead, since the config is watched after it subscribes.
+		onChange := <-loader.onChange
+		applied := make(chan struct{})
+		defer config.OnChange("log.levels", func(any) error {
+			close(applied)
+
+			return nil
+		})()
+		onChange(levels("WARN"))
+		<-applied
+		level, _ = log.Default().Level("github.com/nil-go/nilgo/config")
+		assert.Equal(t, slog.LevelWarn, level)
+
+		return nil
+	}))
+}
+
+//nolint:paralleltest // It changes the default config.
+func TestRestartOnChange(t *testing.T) {
+	loader := &watchLoader{
+		values:   map[string]any{"app": map[string]any{"name": "v1"}},
+		onChange: make(chan func(map[string]any), 1),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	names := make(chan string, 2)
+	runner := nilgo.New(
+		config.Bootstrap(config.WithLoader(loader)),
+		nilgo.Named("app", func(ctx context.Context) error {
+			names <- konf.Get[string]("app.name")
+			<-ctx.Done()
+
+			return nil
+		}, config.RestartOnChange("app")),
+	)
+	done := make(chan error, 1)
+	go func() {
+		done <- runner.Run(ctx)
+	}()
+
+	assert.Equal(t, "v1", <-names)
+	(<-loader.onChange)(map[string]any{"app": map[string]any{"name": "v2"}})
+	assert.Equal(t, "v2", <-names)
+
+	cancel()
+	assert.NoError(t, <-done)
+	config.SetDefault(konf.New())
+}
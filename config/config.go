@@ -0,0 +1,120 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package config assembles konf.Config from a declared list of sources,
+// e.g. files with profile overlays, environment variables and flags.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nil-go/konf"
+	kfile "github.com/nil-go/konf/provider/file"
+	kfs "github.com/nil-go/konf/provider/fs"
+	"gopkg.in/yaml.v3"
+)
+
+// New creates a new konf.Config which loads configuration from the sources with the given Option(s).
+// The sources are loaded in the order they are declared, and each source takes precedence over the sources before it.
+//
+// It returns the error if any source fails to load, e.g. the file does not exist.
+// The sources can be re-read by [Reload].
+func New(opts ...Option) (*konf.Config, error) {
+	option := &options{delimiter: "."}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	config := konf.New(option.konfOpts...)
+	var srcs []*source
+	for _, load := range option.sources {
+		loaders, err := load(config, option.profiles)
+		if err != nil {
+			return nil, err
+		}
+		for _, loader := range loaders {
+			src := &source{loader: loader}
+			if err := config.Load(src); err != nil {
+				return nil, fmt.Errorf("load config from %v: %w", loader, err)
+			}
+			srcs = append(srcs, src)
+		}
+	}
+
+	trackedMutex.Lock()
+	trackedConfigs[config] = &tracked{sources: srcs, konfOpts: option.konfOpts, delimiter: option.delimiter}
+	trackedMutex.Unlock()
+
+	return config, nil
+}
+
+// fileLoaders returns loaders for the file and its profile overlays, e.g. `config.prod.yaml` for `config.yaml`.
+// The overlays are skipped if they do not exist.
+func fileLoaders(
+	name string,
+	profiles []string,
+	exists func(string) bool,
+	loader func(string, func([]byte, any) error) konf.Loader,
+) ([]konf.Loader, error) {
+	unmarshal, err := unmarshaler(name)
+	if err != nil {
+		return nil, err
+	}
+
+	loaders := []konf.Loader{loader(name, unmarshal)}
+	ext := path.Ext(name)
+	for _, profile := range profiles {
+		overlay := strings.TrimSuffix(name, ext) + "." + profile + ext
+		if exists(overlay) {
+			loaders = append(loaders, loader(overlay, unmarshal))
+		}
+	}
+
+	return loaders, nil
+}
+
+func unmarshaler(name string) (func([]byte, any) error, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return yaml.Unmarshal, nil
+	case ".json":
+		return json.Unmarshal, nil
+	default:
+		return nil, fmt.Errorf("unsupported config file %s: %w", name, errUnsupportedFormat)
+	}
+}
+
+func osFileLoaders(name string, profiles []string) ([]konf.Loader, error) {
+	return fileLoaders(name, profiles,
+		func(name string) bool {
+			_, err := os.Stat(name)
+
+			return err == nil
+		},
+		func(name string, unmarshal func([]byte, any) error) konf.Loader {
+			return kfile.New(name, kfile.WithUnmarshal(unmarshal))
+		},
+	)
+}
+
+func fsFileLoaders(fsys fs.FS, name string, profiles []string) ([]konf.Loader, error) {
+	return fileLoaders(name, profiles,
+		func(name string) bool {
+			_, err := fs.Stat(fsys, name)
+
+			return err == nil
+		},
+		func(name string, unmarshal func([]byte, any) error) konf.Loader {
+			return kfs.New(fsys, name, kfs.WithUnmarshal(unmarshal))
+		},
+	)
+}
+
+var errUnsupportedFormat = errors.New("unsupported format")
@@ -0,0 +1,192 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/provider/env"
+
+	"github.com/nil-go/nilgo/config"
+	"github.com/nil-go/nilgo/config/internal/assert"
+)
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("app:\n  name: nilgo\n  port: 8080\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config.prod.yaml"), []byte("app:\n  port: 80\n"), 0o600))
+	t.Setenv("APP_ENV", "test")
+
+	testcases := []struct {
+		description string
+		opts        []config.Option
+		expected    map[string]string
+		err         string
+	}{
+		{
+			description: "file",
+			opts:        []config.Option{config.WithFile(filepath.Join(dir, "config.yaml"))},
+			expected:    map[string]string{"app.name": "nilgo", "app.port": "8080"},
+		},
+		{
+			description: "file with profile",
+			opts: []config.Option{
+				config.WithFile(filepath.Join(dir, "config.yaml")),
+				config.WithProfile("prod", "staging"),
+			},
+			expected: map[string]string{"app.name": "nilgo", "app.port": "80"},
+		},
+		{
+			description: "fs with env",
+			opts: []config.Option{
+				config.WithFS(fstest.MapFS{
+					"config.json":      {Data: []byte(`{"app":{"name":"fs","env":"dev"}}`)},
+					"config.prod.json": {Data: []byte(`{"app":{"name":"prod"}}`)},
+				}, "config.json"),
+				config.WithEnv(env.WithPrefix("APP_")),
+				config.WithProfile("prod"),
+			},
+			expected: map[string]string{"app.name": "prod", "app.env": "test"},
+		},
+		{
+			description: "file not found",
+			opts:        []config.Option{config.WithFile(filepath.Join(dir, "not_found.yaml"))},
+			err: "load config from file://" + filepath.Join(dir, "not_found.yaml") +
+				": load configuration: read file: open " + filepath.Join(dir, "not_found.yaml") +
+				": no such file or directory",
+		},
+		{
+			description: "unsupported format",
+			opts:        []config.Option{config.WithFile("config.toml")},
+			err:         "unsupported config file config.toml: unsupported format",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			cfg, err := config.New(testcase.opts...)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+
+				return
+			}
+			assert.NoError(t, err)
+			for path, expected := range testcase.expected {
+				var value string
+				assert.NoError(t, cfg.Unmarshal(path, &value))
+				assert.Equal(t, expected, value)
+			}
+		})
+	}
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.New(
+		config.WithFS(fstest.MapFS{
+			"config.json":      {Data: []byte(`{"app":{"name":"fs","port":8080,"password":"secret"}}`)},
+			"config.prod.json": {Data: []byte(`{"app":{"name":"prod (eu)"}}`)},
+		}, "config.json"),
+		config.WithProfile("prod"),
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []config.Value{
+		{
+			Path: "app.name", Value: "prod (eu)", Loader: "fs:///config.prod.json",
+			Overridden: []config.Override{{Value: "fs", Loader: "fs:///config.json"}},
+		},
+		{Path: "app.password", Value: "******", Loader: "fs:///config.json"},
+		{Path: "app.port", Value: "8080", Loader: "fs:///config.json"},
+	}, values(t, cfg, "app"))
+	assert.Equal(t, 0, len(values(t, cfg, "not_found")))
+}
+
+func TestValues_delimiter(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.New(
+		config.WithLoader(namedLoader{name: "first", values: map[string]any{"App": map[string]any{"Name": "nilgo"}}}),
+		config.WithDelimiter("/"),
+		config.WithOption(konf.WithCaseSensitive()),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []config.Value{{Path: "App/Name", Value: "nilgo", Loader: "first"}}, values(t, cfg, "App"))
+}
+
+func TestValues_text(t *testing.T) {
+	t.Parallel()
+
+	const (
+		certificate = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+		marker      = "a] that is loaded by loader[b].\nHere are other value(loader)s:\n  - c(d)"
+	)
+	loaded := func(name string) map[string]any {
+		return map[string]any{"app": map[string]any{"certificate": certificate, "name": name, "marker": marker}}
+	}
+	expected := []config.Value{
+		{Path: "app.certificate", Value: certificate, Loader: "first"},
+		{Path: "app.marker", Value: marker, Loader: "first"},
+		{
+			Path: "app.name", Value: "second (x)", Loader: "second",
+			Overridden: []config.Override{{Value: marker, Loader: "first"}},
+		},
+	}
+
+	tracked, err := config.New(config.WithLoader(
+		namedLoader{name: "first", values: loaded(marker)},
+		namedLoader{name: "second", values: map[string]any{"app": map[string]any{"name": "second (x)"}}},
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, values(t, tracked, "app"))
+}
+
+func TestValues_untracked(t *testing.T) {
+	t.Parallel()
+
+	untracked := konf.New()
+	assert.NoError(t, untracked.Load(namedLoader{name: "first", values: map[string]any{"app": "nilgo"}}))
+	_, err := config.Values(untracked, "app")
+	assert.Equal(t, config.ErrNotTracked, err)
+}
+
+func TestRelease(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.New(config.WithLoader(namedLoader{name: "first", values: map[string]any{"app": "nilgo"}}))
+	assert.NoError(t, err)
+	assert.Equal(t, []config.Value{{Path: "app", Value: "nilgo", Loader: "first"}}, values(t, cfg, "app"))
+
+	config.Release(cfg)
+	_, err = config.Values(cfg, "app")
+	assert.Equal(t, config.ErrNotTracked, err)
+	assert.NoError(t, config.Reload(cfg))
+}
+
+func values(t *testing.T, cfg *konf.Config, path string) []config.Value {
+	t.Helper()
+
+	values, err := config.Values(cfg, path)
+	assert.NoError(t, err)
+
+	return values
+}
+
+type namedLoader struct {
+	name   string
+	values map[string]any
+}
+
+func (n namedLoader) Load() (map[string]any, error) {
+	return n.values, nil
+}
+
+func (n namedLoader) String() string {
+	return n.name
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/nil-go/konf"
+)
+
+type (
+	// Value is the resolved value of the key, along with the loader which provides it
+	// and the values from other loaders which are overridden.
+	Value struct {
+		Path       string     `json:"path"`
+		Value      string     `json:"value"`
+		Loader     string     `json:"loader"`
+		Overridden []Override `json:"overridden,omitempty"`
+	}
+	// Override is the value from the loader which is overridden by the loaders after it.
+	Override struct {
+		Value  string `json:"value"`
+		Loader string `json:"loader"`
+	}
+)
+
+// Values lists the resolved value of each key under the path in the given konf.Config,
+// or the default konf.Config if it's nil. The values are ordered by the path of key,
+// and the values of sensitive keys are blurred, e.g. password.
+//
+// It resolves the values from the loaders of the config created by [New], or set by [SetDefault].
+// It returns [ErrNotTracked] if the config is not created by New.
+func Values(config *konf.Config, path string) ([]Value, error) {
+	if config == nil {
+		subscriptionsMutex.Lock()
+		config = defaultConfig
+		subscriptionsMutex.Unlock()
+	}
+	tracked := trackedConfig(config)
+	if tracked == nil {
+		return nil, ErrNotTracked
+	}
+
+	return tracked.values(config, path), nil
+}
+
+func (t *tracked) values(config *konf.Config, path string) []Value {
+	// The values of each source under the path, which are transformed as same as the config.
+	layers := make([]map[string]any, len(t.sources))
+	for i, src := range t.sources {
+		layer := konf.New(t.konfOpts...)
+		_ = layer.Load(staticLoader(src.current())) // It never fails.
+		layers[i] = make(map[string]any)
+		for _, key := range leaves(layer.Unmarshal, path, t.delimiter) {
+			layers[i][key.path] = key.value
+		}
+	}
+
+	keys := leaves(config.Unmarshal, path, t.delimiter)
+	values := make([]Value, 0, len(keys))
+	for _, key := range keys {
+		var value Value
+		for i := len(layers) - 1; i >= 0; i-- {
+			val, exist := layers[i][key.path]
+			if !exist {
+				continue
+			}
+			loader := t.sources[i].String()
+			if value.Path == "" {
+				value = Value{Path: key.path, Value: blur(key.path, val, true), Loader: loader}
+
+				continue
+			}
+			value.Overridden = append(value.Overridden, Override{Value: blur(key.path, val, true), Loader: loader})
+		}
+		if value.Path != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+type leaf struct {
+	path  string
+	value any
+}
+
+// leaves returns the leaf values under the path, ordered by the path of key.
+func leaves(unmarshal func(string, any) error, path, delimiter string) []leaf {
+	var value any
+	if err := unmarshal(path, &value); err != nil {
+		return nil
+	}
+
+	var values []leaf
+	var walk func(string, any)
+	walk = func(path string, value any) {
+		switch val := value.(type) {
+		case nil:
+		case map[string]any:
+			for key, v := range val {
+				p := key
+				if path != "" {
+					p = path + delimiter + key
+				}
+				walk(p, v)
+			}
+		default:
+			values = append(values, leaf{path: path, value: value})
+		}
+	}
+	walk(path, value)
+	sort.Slice(values, func(i, j int) bool { return values[i].path < values[j].path })
+
+	return values
+}
+
+type staticLoader map[string]any
+
+func (s staticLoader) Load() (map[string]any, error) {
+	return s, nil
+}
+
+func (s staticLoader) String() string {
+	return ""
+}
+
+// ErrNotTracked is returned by [Values] for the konf.Config which is not created by [New].
+var ErrNotTracked = errors.New("config is not created by config.New")
@@ -0,0 +1,25 @@
+module github.com/nil-go/nilgo/config
+
+go 1.22
+
+require (
+	github.com/nil-go/konf v1.4.0
+	github.com/nil-go/konf/provider/file v1.4.0
+	github.com/nil-go/nilgo v0.3.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/fsnotify/fsnotify v1.8.0 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/otel v1.32.0 // indirect
+	go.opentelemetry.io/otel/metric v1.32.0 // indirect
+	go.opentelemetry.io/otel/trace v1.32.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+)
+
+// The modules in this repository are developed together and tagged with the same version at the same commit,
+// root and config first (see .github/workflows/release.yml). The replacements only apply to the development here,
+// since Go ignores them in dependencies.
+replace github.com/nil-go/nilgo => ../
@@ -0,0 +1,51 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/nil-go/nilgo/log"
+)
+
+// logLevel is the log level in config `log.levels`.
+// The name is not the key of map since it may contain the delimiter of config path.
+type logLevel struct {
+	Name  string
+	Level string
+}
+
+// applyLogLevels returns the function to apply the log levels in config to the given log.Control.
+// The levels removed from config are reset, while the levels set at runtime with other names are kept.
+func applyLogLevels(control *log.Control) func([]logLevel) error {
+	applied := make(map[string]slog.Level)
+
+	return func(levels []logLevel) error {
+		values := make(map[string]slog.Level, len(levels))
+		for _, value := range levels {
+			var level slog.Level
+			if err := level.UnmarshalText([]byte(value.Level)); err != nil {
+				return fmt.Errorf("parse log level of %q: %w", value.Name, err)
+			}
+			values[value.Name] = level
+		}
+
+		for name := range applied {
+			if _, exist := values[name]; !exist {
+				control.Reset(name)
+			}
+		}
+		for name, level := range values {
+			if current, exist := applied[name]; !exist || current != level {
+				control.Set(name, level, 0)
+			}
+		}
+		applied = values
+
+		return nil
+	}
+}
+
+const levelsPath = "log.levels"
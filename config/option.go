@@ -0,0 +1,101 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package config
+
+import (
+	"io/fs"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/provider/env"
+	"github.com/nil-go/konf/provider/flag"
+)
+
+// WithFile provides the configuration files in YAML or JSON format, which are watched for changes.
+// For each profile provided by [WithProfile], it also loads the overlay file if it exists,
+// e.g. `config.prod.yaml` for `config.yaml` with profile `prod`.
+func WithFile(files ...string) Option {
+	return func(options *options) {
+		for _, file := range files {
+			options.sources = append(options.sources, func(_ *konf.Config, profiles []string) ([]konf.Loader, error) {
+				return osFileLoaders(file, profiles)
+			})
+		}
+	}
+}
+
+// WithFS provides the configuration files in YAML or JSON format from the fs.FS, e.g. embed.FS.
+// Same as [WithFile], it also loads the overlay files of profiles if they exist.
+func WithFS(fsys fs.FS, files ...string) Option {
+	return func(options *options) {
+		for _, file := range files {
+			options.sources = append(options.sources, func(_ *konf.Config, profiles []string) ([]konf.Loader, error) {
+				return fsFileLoaders(fsys, file, profiles)
+			})
+		}
+	}
+}
+
+// WithEnv loads configuration from environment variables with the given env.Option(s).
+func WithEnv(opts ...env.Option) Option {
+	return func(options *options) {
+		options.sources = append(options.sources, func(*konf.Config, []string) ([]konf.Loader, error) {
+			return []konf.Loader{env.New(opts...)}, nil
+		})
+	}
+}
+
+// WithFlag loads configuration from command-line flags with the given flag.Option(s).
+// It uses flag.CommandLine by default, which should be parsed before loading.
+func WithFlag(opts ...flag.Option) Option {
+	return func(options *options) {
+		options.sources = append(options.sources, func(config *konf.Config, _ []string) ([]konf.Loader, error) {
+			return []konf.Loader{flag.New(config, opts...)}, nil
+		})
+	}
+}
+
+// WithLoader provides custom konf.Loader(s) as the sources.
+func WithLoader(loaders ...konf.Loader) Option {
+	return func(options *options) {
+		options.sources = append(options.sources, func(*konf.Config, []string) ([]konf.Loader, error) {
+			return loaders, nil
+		})
+	}
+}
+
+// WithProfile provides the profiles to overlay the configuration files, e.g. `prod`.
+// The overlays are applied in the order of profiles.
+func WithProfile(profiles ...string) Option {
+	return func(options *options) {
+		options.profiles = append(options.profiles, profiles...)
+	}
+}
+
+// WithDelimiter provides the delimiter of the config path, which is `.` by default.
+// It should be used instead of konf.WithDelimiter, so [Values] resolves the paths with the same delimiter.
+func WithDelimiter(delimiter string) Option {
+	return func(options *options) {
+		options.delimiter = delimiter
+		options.konfOpts = append(options.konfOpts, konf.WithDelimiter(delimiter))
+	}
+}
+
+// WithOption provides konf.Option(s) to create the konf.Config.
+// The delimiter of the config path should be provided by [WithDelimiter] instead.
+func WithOption(opts ...konf.Option) Option {
+	return func(options *options) {
+		options.konfOpts = append(options.konfOpts, opts...)
+	}
+}
+
+type (
+	// Option configures the konf.Config with specific options.
+	Option  func(*options)
+	options struct {
+		sources   []func(*konf.Config, []string) ([]konf.Loader, error)
+		profiles  []string
+		konfOpts  []konf.Option
+		delimiter string
+	}
+)
@@ -0,0 +1,196 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nil-go/konf"
+)
+
+// Reload re-reads all sources of the konf.Config created by [New], and applies the changes
+// as same as the changes from watching sources, e.g. [OnChange] subscribers are notified.
+// It keeps the values of the source which fails to reload, and returns the errors.
+//
+// It requires the config is watched, e.g. by [Bootstrap], and the reload is deferred until it's watched.
+// It does nothing for the konf.Config which is not created by New.
+func Reload(config *konf.Config) error {
+	tracked := trackedConfig(config)
+	if tracked == nil {
+		return nil
+	}
+
+	errs := make([]error, 0, len(tracked.sources))
+	for _, src := range tracked.sources {
+		errs = append(errs, src.reload())
+	}
+
+	return errors.Join(errs...)
+}
+
+// Release stops tracking the konf.Config created by [New] once it's no longer used, e.g. it's replaced,
+// so it can be garbage collected along with its subscriptions, e.g. by [WatchValues].
+// After release, [Values] and [Reload] treat it as the konf.Config which is not created by New.
+func Release(config *konf.Config) {
+	trackedMutex.Lock()
+	delete(trackedConfigs, config)
+	trackedMutex.Unlock()
+
+	subscriptionsMutex.Lock()
+	defer subscriptionsMutex.Unlock()
+	for id, sub := range subscriptions {
+		if sub.config == config {
+			delete(subscriptions, id)
+		}
+	}
+	if config != defaultConfig {
+		delete(registered, config)
+	}
+}
+
+// tracked is the konf.Config created by New, along with its sources and options,
+// so the values of each source can be reloaded and explained.
+type tracked struct {
+	sources   []*source
+	konfOpts  []konf.Option
+	delimiter string
+}
+
+func trackedConfig(config *konf.Config) *tracked {
+	trackedMutex.RLock()
+	defer trackedMutex.RUnlock()
+
+	return trackedConfigs[config]
+}
+
+// source wraps the konf.Loader to reload it on demand.
+// It implements konf.Watcher so the reloaded values are applied through the callback of konf.Config.Watch.
+// It also keeps the latest values of the loader for explanation.
+type source struct {
+	loader konf.Loader
+	values atomic.Pointer[map[string]any]
+
+	mutex    sync.Mutex
+	ctx      context.Context //nolint:containedctx // It's the context of watching.
+	onChange func(map[string]any)
+	pending  bool
+}
+
+func (s *source) Load() (map[string]any, error) {
+	values, err := s.loader.Load()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	s.store(values)
+
+	return values, nil
+}
+
+func (s *source) Watch(ctx context.Context, notify func(map[string]any)) error {
+	onChange := func(values map[string]any) {
+		s.store(values)
+		notify(values)
+	}
+
+	s.mutex.Lock()
+	s.ctx, s.onChange = ctx, onChange
+	if s.pending {
+		// Apply the reload requested before watching.
+		s.pending = false
+		if err := s.apply(); err != nil {
+			slog.LogAttrs(ctx, slog.LevelWarn, "Fail to reload config.", slog.Any("error", err))
+		}
+	}
+	s.mutex.Unlock()
+	defer func() {
+		s.mutex.Lock()
+		s.ctx, s.onChange = nil, nil
+		s.mutex.Unlock()
+	}()
+
+	if watcher, ok := s.loader.(konf.Watcher); ok {
+		return watcher.Watch(ctx, onChange) //nolint:wrapcheck
+	}
+	<-ctx.Done()
+
+	return nil
+}
+
+func (s *source) Status(onStatus func(bool, error)) {
+	if statuser, ok := s.loader.(konf.Statuser); ok {
+		statuser.Status(onStatus)
+	}
+}
+
+func (s *source) String() string {
+	return fmt.Sprint(s.loader)
+}
+
+// store keeps the copy of values since konf.Config transforms the keys of values in place.
+func (s *source) store(values map[string]any) {
+	values = copyMap(values)
+	s.values.Store(&values)
+}
+
+// current returns the copy of the latest values of the loader.
+func (s *source) current() map[string]any {
+	if values := s.values.Load(); values != nil {
+		return copyMap(*values)
+	}
+
+	return nil
+}
+
+func copyMap(values map[string]any) map[string]any {
+	if values == nil {
+		return nil
+	}
+
+	copied := make(map[string]any, len(values))
+	for key, value := range values {
+		if m, ok := value.(map[string]any); ok {
+			value = copyMap(m)
+		}
+		copied[key] = value
+	}
+
+	return copied
+}
+
+func (s *source) reload() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.onChange == nil {
+		s.pending = true // Reload once it's watched.
+
+		return nil
+	}
+	if s.ctx.Err() != nil {
+		return nil // It has stopped watching.
+	}
+
+	return s.apply()
+}
+
+func (s *source) apply() error {
+	values, err := s.loader.Load()
+	if err != nil {
+		return fmt.Errorf("reload config from %v: %w", s.loader, err)
+	}
+	s.onChange(values)
+
+	return nil
+}
+
+//nolint:gochecknoglobals
+var (
+	trackedConfigs = make(map[*konf.Config]*tracked)
+	trackedMutex   sync.RWMutex
+)
@@ -0,0 +1,322 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/nil-go/konf"
+)
+
+// OnChange subscribes the changes of the value under the path in the default konf.Config,
+// and applies the new value decoded into T in place, e.g. the timeout of HTTP handler.
+//
+// Each applied change is logged with the before/after diff, in which sensitive values are blurred.
+// If apply returns error, the change is logged as warning and it is diffed again with the next change.
+// The apply function must be non-blocking and usually completes instantly.
+// The subscriptions to the same path are applied in the order of subscription.
+//
+// It requires the default konf.Config is watched, e.g. by [Bootstrap].
+// It returns a function to unsubscribe the changes.
+func OnChange[T any](path string, apply func(T) error) func() {
+	var (
+		mutex  sync.Mutex
+		before = value(path)
+	)
+
+	return subscribe(nil, func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		after := value(path)
+		diffs := diff(path, before, after)
+		if len(diffs) == 0 {
+			return
+		}
+
+		var target T
+		if err := konf.Unmarshal(path, &target); err != nil {
+			slog.LogAttrs(context.Background(), slog.LevelWarn, "Fail to decode config change.",
+				slog.String("path", path),
+				slog.Any("diff", diffs),
+				slog.Any("error", err),
+			)
+
+			return
+		}
+		if err := apply(target); err != nil {
+			slog.LogAttrs(context.Background(), slog.LevelWarn, "Fail to apply config change.",
+				slog.String("path", path),
+				slog.Any("diff", diffs),
+				slog.Any("error", err),
+			)
+
+			return
+		}
+		before = after
+		slog.LogAttrs(context.Background(), slog.LevelInfo, "Config change has been applied.",
+			slog.String("path", path),
+			slog.Any("diff", diffs),
+		)
+	})
+}
+
+// WatchValues calls onChange with the values under the path in the given konf.Config(s) (see [Values]),
+// at first and then each time they change, until ctx is done or onChange returns error.
+// It uses the default konf.Config if configs are not provided.
+//
+// It requires the configs are watched, e.g. by [Bootstrap].
+// It returns [ErrNotTracked] if any config is not created by [New].
+func WatchValues(ctx context.Context, path string, onChange func([]Value) error, configs ...*konf.Config) error {
+	if len(configs) == 0 {
+		configs = []*konf.Config{nil}
+	}
+
+	changed := make(chan struct{}, 1)
+	stops := make([]func(), 0, len(configs))
+	for _, config := range configs {
+		stops = append(stops, subscribe(config, func() {
+			select {
+			case changed <- struct{}{}:
+			default: // There is a pending change already.
+			}
+		}))
+	}
+	defer func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}()
+
+	var last []Value
+	for {
+		values := []Value{}
+		for _, config := range configs {
+			vals, err := Values(config, path)
+			if err != nil {
+				return err
+			}
+			values = append(values, vals...)
+		}
+		if last == nil || !reflect.DeepEqual(last, values) {
+			if err := onChange(values); err != nil {
+				return err
+			}
+			last = values
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+// SetDefault sets the given konf.Config as the default konf.Config, as same as konf.SetDefault.
+// It also moves the subscriptions to the default konf.Config, e.g. by [OnChange], to the given config,
+// and notifies them if the values under their paths are different in the given config.
+//
+// It should be used instead of konf.SetDefault if the default konf.Config is subscribed.
+func SetDefault(config *konf.Config) {
+	if config == nil {
+		return
+	}
+
+	subscriptionsMutex.Lock()
+	konf.SetDefault(config)
+	defaultConfig = config
+	register(config)
+	notifies := subscribed(nil)
+	subscriptionsMutex.Unlock()
+
+	for _, notify := range notifies {
+		notify()
+	}
+}
+
+// subscribe registers the notify function which is called once the value under the path
+// in the config, or the default konf.Config if it's nil, changes. It returns a function to unsubscribe.
+//
+// Since konf.Config can not unregister callbacks, it registers only one callback to each config (see register),
+// which notifies the subscriptions to the config at the time. So the subscription is removed on unsubscribe,
+// and the subscriptions to the default konf.Config follow the new default konf.Config set by [SetDefault].
+// The notify function may be called for the changes of other paths, so it should check the change itself.
+func subscribe(config *konf.Config, notify func()) func() {
+	subscriptionsMutex.Lock()
+	defer subscriptionsMutex.Unlock()
+
+	switch {
+	case config != nil:
+		register(config)
+	case defaultConfig != nil:
+		register(defaultConfig)
+	case !globalRegistered:
+		// The default konf.Config has not been set by SetDefault.
+		globalRegistered = true
+		konf.OnChange(func() {
+			subscriptionsMutex.Lock()
+			var notifies []func()
+			if defaultConfig == nil {
+				notifies = subscribed(nil)
+			}
+			subscriptionsMutex.Unlock()
+
+			for _, notify := range notifies {
+				notify()
+			}
+		})
+	}
+
+	id := nextSubscription
+	nextSubscription++
+	subscriptions[id] = subscription{config: config, notify: notify}
+
+	return func() {
+		subscriptionsMutex.Lock()
+		defer subscriptionsMutex.Unlock()
+
+		delete(subscriptions, id)
+	}
+}
+
+// register registers the callback to the config once, which notifies the subscriptions to the config,
+// and the subscriptions to the default konf.Config while the config is the default.
+// It must be called with subscriptionsMutex locked.
+func register(config *konf.Config) {
+	if _, exist := registered[config]; exist {
+		return
+	}
+	registered[config] = struct{}{}
+	// The callback registered to the root path is called for the changes of any path.
+	config.OnChange(func(*konf.Config) {
+		subscriptionsMutex.Lock()
+		notifies := subscribed(config)
+		if config == defaultConfig {
+			notifies = append(notifies, subscribed(nil)...)
+		}
+		subscriptionsMutex.Unlock()
+
+		for _, notify := range notifies {
+			notify()
+		}
+	})
+}
+
+// subscribed returns the notify functions of the subscriptions to the config in the order of subscription.
+// It must be called with subscriptionsMutex locked.
+func subscribed(config *konf.Config) []func() {
+	ids := make([]uint64, 0, len(subscriptions))
+	for id, sub := range subscriptions {
+		if sub.config == config {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	notifies := make([]func(), 0, len(ids))
+	for _, id := range ids {
+		notifies = append(notifies, subscriptions[id].notify)
+	}
+
+	return notifies
+}
+
+type subscription struct {
+	config *konf.Config
+	notify func()
+}
+
+func value(path string) any {
+	var val any
+	if err := konf.Unmarshal(path, &val); err != nil {
+		return nil
+	}
+
+	return val
+}
+
+// diff returns the changes between before and after values in format `path: before -> after`,
+// ordered by path and with sensitive values blurred.
+func diff(path string, before, after any) []string {
+	beforeValues := make(map[string]any)
+	flatten(beforeValues, path, before)
+	afterValues := make(map[string]any)
+	flatten(afterValues, path, after)
+
+	keys := make([]string, 0, len(beforeValues)+len(afterValues))
+	for key := range beforeValues {
+		keys = append(keys, key)
+	}
+	for key := range afterValues {
+		if _, exist := beforeValues[key]; !exist {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var diffs []string
+	for _, key := range keys {
+		beforeValue, beforeExist := beforeValues[key]
+		afterValue, afterExist := afterValues[key]
+		if beforeExist && afterExist && reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+		diffs = append(diffs,
+			fmt.Sprintf("%s: %s -> %s", key, blur(key, beforeValue, beforeExist), blur(key, afterValue, afterExist)),
+		)
+	}
+
+	return diffs
+}
+
+func flatten(values map[string]any, path string, value any) {
+	if value == nil {
+		return
+	}
+	if m, ok := value.(map[string]any); ok {
+		for key, val := range m {
+			p := key
+			if path != "" {
+				p = path + "." + key
+			}
+			flatten(values, p, val)
+		}
+
+		return
+	}
+	values[path] = value
+}
+
+func blur(path string, value any, exist bool) string {
+	switch {
+	case !exist:
+		return "<unset>"
+	case sensitive.MatchString(path):
+		return "******"
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+//nolint:gochecknoglobals
+var (
+	subscriptions      = make(map[uint64]subscription)
+	nextSubscription   uint64
+	subscriptionsMutex sync.Mutex
+	// registered records the configs which have the callback registered.
+	registered = make(map[*konf.Config]struct{})
+	// globalRegistered is true if the callback is registered to the default konf.Config before SetDefault.
+	globalRegistered bool
+	// defaultConfig is the default konf.Config set by SetDefault.
+	defaultConfig *konf.Config
+)
+
+//nolint:gochecknoglobals
+var sensitive = regexp.MustCompile(`(?i)password|passwd|pass|pwd|pw|secret|token|apiKey|bearer|cred`)
@@ -0,0 +1,233 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nil-go/konf"
+
+	"github.com/nil-go/nilgo/config"
+	"github.com/nil-go/nilgo/config/internal/assert"
+)
+
+//nolint:paralleltest // It changes the default config and logger.
+func TestOnChange(t *testing.T) {
+	buf := &syncBuffer{}
+	logger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})))
+	defer slog.SetDefault(logger)
+
+	loader := &watchLoader{
+		values:   map[string]any{"app": map[string]any{"port": 8080, "password": "old"}},
+		onChange: make(chan func(map[string]any), 1),
+	}
+	cfg := konf.New()
+	assert.NoError(t, cfg.Load(loader))
+	config.SetDefault(cfg)
+	defer config.SetDefault(konf.New())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, cfg.Watch(ctx))
+	}()
+
+	type app struct {
+		Port     int
+		Password string
+	}
+	applied := make(chan app, 2)
+	stop := config.OnChange("app", func(value app) error {
+		applied <- value
+		if value.Port == 0 {
+			return errors.New("invalid port")
+		}
+
+		return nil
+	})
+	defer stop()
+
+	onChange := <-loader.onChange
+	onChange(map[string]any{"app": map[string]any{"password": "new"}})
+	assert.Equal(t, app{Password: "new"}, <-applied)
+	onChange(map[string]any{"app": map[string]any{"port": 80, "password": "new", "name": "nilgo"}})
+	assert.Equal(t, app{Port: 80, Password: "new"}, <-applied)
+	buf.WaitFor("Config change has been applied.")
+
+	assert.Equal(t, `level=WARN msg="Fail to apply config change." path=app`+
+		` diff="[app.password: ****** -> ****** app.port: 8080 -> <unset>]" error="invalid port"
+level=INFO msg="Config change has been applied." path=app`+
+		` diff="[app.name: <unset> -> nilgo app.password: ****** -> ****** app.port: 8080 -> 80]"
+`, strings.ReplaceAll(buf.String(), `level=INFO msg="Configuration has been changed." loader=watch
+`, ""))
+}
+
+//nolint:paralleltest // It changes the default config.
+func TestSetDefault(t *testing.T) {
+	applied := make(chan string, 3)
+	defer config.OnChange("app.name", func(name string) error {
+		applied <- name
+
+		return nil
+	})()
+	// Subscribe the same path again, which is removed once unsubscribed.
+	config.OnChange("app.name", func(string) error { return nil })()
+
+	loader := &watchLoader{
+		values:   map[string]any{"app": map[string]any{"name": "v1"}},
+		onChange: make(chan func(map[string]any), 1),
+	}
+	cfg := konf.New()
+	assert.NoError(t, cfg.Load(loader))
+	config.SetDefault(cfg)
+	defer config.SetDefault(konf.New())
+	assert.Equal(t, "v1", <-applied)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, cfg.Watch(ctx))
+	}()
+	(<-loader.onChange)(map[string]any{"app": map[string]any{"name": "v2"}})
+	assert.Equal(t, "v2", <-applied)
+
+	// The subscriptions move to the new default config which replaces the watched one.
+	replaced := konf.New()
+	assert.NoError(t, replaced.Load(namedLoader{name: "replaced", values: map[string]any{"app": map[string]any{"name": "v3"}}}))
+	config.SetDefault(replaced)
+	assert.Equal(t, "v3", <-applied)
+}
+
+type syncBuffer struct {
+	buf   bytes.Buffer
+	mutex sync.Mutex
+	cond  *sync.Cond
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cond != nil {
+		defer s.cond.Broadcast()
+	}
+
+	return s.buf.Write(p)
+}
+
+// WaitFor waits until the buffer contains the given text.
+func (s *syncBuffer) WaitFor(text string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cond == nil {
+		s.cond = sync.NewCond(&s.mutex)
+	}
+
+	for !strings.Contains(s.buf.String(), text) {
+		s.cond.Wait()
+	}
+}
+
+func (s *syncBuffer) String() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.buf.String()
+}
+
+type watchLoader struct {
+	values   map[string]any
+	onChange chan func(map[string]any)
+}
+
+func (w *watchLoader) Load() (map[string]any, error) {
+	return w.values, nil
+}
+
+func (w *watchLoader) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	w.onChange <- onChange
+	<-ctx.Done()
+
+	return nil
+}
+
+func (w *watchLoader) String() string {
+	return "watch"
+}
+
+func TestWatchValues(t *testing.T) {
+	t.Parallel()
+
+	loader := &watchLoader{
+		values:   map[string]any{"app": map[string]any{"name": "v1"}},
+		onChange: make(chan func(map[string]any), 1),
+	}
+	cfg, err := config.New(config.WithLoader(loader))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, cfg.Watch(ctx))
+	}()
+
+	changes := make(chan []config.Value, 2)
+	done := make(chan error, 1)
+	go func() {
+		done <- config.WatchValues(ctx, "app", func(values []config.Value) error {
+			changes <- values
+
+			return nil
+		}, cfg)
+	}()
+
+	assert.Equal(t, []config.Value{{Path: "app.name", Value: "v1", Loader: "watch"}}, <-changes)
+	(<-loader.onChange)(map[string]any{"app": map[string]any{"name": "v2"}})
+	assert.Equal(t, []config.Value{{Path: "app.name", Value: "v2", Loader: "watch"}}, <-changes)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+//nolint:paralleltest // It measures the heap.
+func TestWatchValues_distinctPaths(t *testing.T) {
+	cfg, err := config.New(config.WithLoader(namedLoader{name: "first", values: map[string]any{"app": "nilgo"}}))
+	assert.NoError(t, err)
+	defer config.Release(cfg)
+
+	heap := func() int64 {
+		runtime.GC()
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+
+		return int64(stats.HeapAlloc) //nolint:gosec
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // It returns once the values have been sent.
+	before := heap()
+	for i := range 10000 {
+		err := config.WatchValues(ctx, "app.key"+strconv.Itoa(i), func([]config.Value) error { return nil }, cfg)
+		assert.NoError(t, err)
+	}
+	// The subscriptions of the paths are removed once the watches end.
+	if growth := heap() - before; growth > 1<<20 {
+		t.Errorf("heap grows %d bytes by watching distinct paths", growth)
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package container tunes the Go runtime for the CPU quota and memory limit of the container,
+// which are read from cgroup v1 or v2 when the process starts.
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Decision is the result of tuning the Go runtime for the container limits.
+type Decision struct {
+	// CPUQuota is the number of CPUs which the container is allowed to use. It's zero if it's unlimited.
+	CPUQuota float64 `json:"cpuQuota,omitempty"`
+	// MemoryLimit is the memory limit of the container in bytes. It's zero if it's unlimited.
+	MemoryLimit int64 `json:"memoryLimit,omitempty"`
+	// GOMAXPROCS is the value of GOMAXPROCS after tuning.
+	GOMAXPROCS int `json:"gomaxprocs"`
+	// GOMAXPROCSSource is where GOMAXPROCS comes from, which is `env`, `cgroup` or `default`.
+	GOMAXPROCSSource string `json:"gomaxprocsSource"`
+	// GOMEMLIMIT is the value of GOMEMLIMIT in bytes after tuning.
+	GOMEMLIMIT int64 `json:"gomemlimit"`
+	// GOMEMLIMITSource is where GOMEMLIMIT comes from, which is `env`, `cgroup` or `default`.
+	GOMEMLIMITSource string `json:"gomemlimitSource"`
+}
+
+// Tune reads the CPU quota and memory limit of the container from cgroup,
+// sets GOMAXPROCS to the CPU quota rounded up, and GOMEMLIMIT to the ratio of the memory limit
+// (see [WithMemoryRatio]), then logs the decision. It keeps the values of GOMAXPROCS and GOMEMLIMIT
+// if they are provided by the environment variables, and the defaults if the container is not limited.
+//
+// The last decision is reported by [Tuned].
+func Tune(opts ...Option) (Decision, error) {
+	option := &options{}
+	for _, opt := range opts {
+		opt(option)
+	}
+	if option.fs == nil {
+		option.fs = os.DirFS("/")
+	}
+	if option.memoryRatio <= 0 || option.memoryRatio > 1 {
+		option.memoryRatio = defaultMemoryRatio
+	}
+
+	limits, err := readLimits(option.fs)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	decision := Decision{
+		CPUQuota:         limits.cpu,
+		MemoryLimit:      limits.memory,
+		GOMAXPROCSSource: "default",
+		GOMEMLIMITSource: "default",
+	}
+	switch _, exist := os.LookupEnv("GOMAXPROCS"); {
+	case exist:
+		decision.GOMAXPROCSSource = "env"
+	case limits.cpu > 0:
+		procs := min(max(int(math.Ceil(limits.cpu)), 1), runtime.NumCPU())
+		runtime.GOMAXPROCS(procs)
+		decision.GOMAXPROCSSource = "cgroup"
+	}
+	switch _, exist := os.LookupEnv("GOMEMLIMIT"); {
+	case exist:
+		decision.GOMEMLIMITSource = "env"
+	case limits.memory > 0:
+		debug.SetMemoryLimit(int64(float64(limits.memory) * option.memoryRatio))
+		decision.GOMEMLIMITSource = "cgroup"
+	}
+	decision.GOMAXPROCS = runtime.GOMAXPROCS(0)
+	decision.GOMEMLIMIT = debug.SetMemoryLimit(-1) // Negative input does not adjust the limit.
+	tuned.Store(&decision)
+
+	slog.LogAttrs(context.Background(), slog.LevelInfo, "Runtime has been tuned for container limits.",
+		slog.Float64("cpuQuota", decision.CPUQuota),
+		slog.Int64("memoryLimit", decision.MemoryLimit),
+		slog.Int("gomaxprocs", decision.GOMAXPROCS),
+		slog.String("gomaxprocsSource", decision.GOMAXPROCSSource),
+		slog.Int64("gomemlimit", decision.GOMEMLIMIT),
+		slog.String("gomemlimitSource", decision.GOMEMLIMITSource),
+	)
+
+	return decision, nil
+}
+
+// Tuned returns the last decision of [Tune]. It returns false if Tune has not been called.
+func Tuned() (Decision, bool) {
+	if decision := tuned.Load(); decision != nil {
+		return *decision, true
+	}
+
+	return Decision{}, false
+}
+
+type limits struct {
+	cpu    float64
+	memory int64
+}
+
+// readLimits reads the limits from cgroup v2 if it's mounted, otherwise from cgroup v1.
+// The limits are zero if the process is not in the cgroup.
+func readLimits(fsys fs.FS) (limits, error) {
+	paths, err := cgroupPaths(fsys)
+	if err != nil {
+		return limits{}, err
+	}
+
+	if _, err := fs.Stat(fsys, "sys/fs/cgroup/cgroup.controllers"); err == nil {
+		return readV2(fsys, paths[""])
+	}
+
+	return readV1(fsys, paths)
+}
+
+func readV2(fsys fs.FS, path string) (limits, error) {
+	var result limits
+	if content, ok, err := readFile(fsys, "sys/fs/cgroup", path, "cpu.max"); err != nil {
+		return limits{}, err
+	} else if ok {
+		// The format is `$MAX $PERIOD`, which MAX is `max` if it's unlimited.
+		fields := strings.Fields(content)
+		if len(fields) != 2 { //nolint:mnd
+			return limits{}, fmt.Errorf("%w: cpu.max: %q", errMalformed, content)
+		}
+		if fields[0] != "max" {
+			if result.cpu, err = quota(fields[0], fields[1]); err != nil {
+				return limits{}, fmt.Errorf("cpu.max: %w", err)
+			}
+		}
+	}
+	if content, ok, err := readFile(fsys, "sys/fs/cgroup", path, "memory.max"); err != nil {
+		return limits{}, err
+	} else if ok && content != "max" {
+		if result.memory, err = strconv.ParseInt(content, 10, 64); err != nil {
+			return limits{}, fmt.Errorf("memory.max: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func readV1(fsys fs.FS, paths map[string]string) (limits, error) {
+	var result limits
+	for _, controller := range []string{"cpu,cpuacct", "cpu"} {
+		path, exist := paths[controller]
+		if !exist {
+			continue
+		}
+		quotaContent, ok, err := readFile(fsys, "sys/fs/cgroup/"+controller, path, "cpu.cfs_quota_us")
+		if err != nil {
+			return limits{}, err
+		}
+		periodContent, periodOK, err := readFile(fsys, "sys/fs/cgroup/"+controller, path, "cpu.cfs_period_us")
+		if err != nil {
+			return limits{}, err
+		}
+		if !ok || !periodOK {
+			continue
+		}
+		// The quota is -1 if it's unlimited.
+		if quotaContent != "-1" {
+			if result.cpu, err = quota(quotaContent, periodContent); err != nil {
+				return limits{}, fmt.Errorf("cpu.cfs_quota_us: %w", err)
+			}
+		}
+
+		break
+	}
+	if path, exist := paths["memory"]; exist {
+		content, ok, err := readFile(fsys, "sys/fs/cgroup/memory", path, "memory.limit_in_bytes")
+		if err != nil {
+			return limits{}, err
+		}
+		if ok {
+			if result.memory, err = strconv.ParseInt(content, 10, 64); err != nil {
+				return limits{}, fmt.Errorf("memory.limit_in_bytes: %w", err)
+			}
+			// It's a huge number close to max int64 if it's unlimited.
+			if result.memory >= unlimitedMemory {
+				result.memory = 0
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// cgroupPaths returns the cgroup paths of the process keyed by controllers,
+// in which the key of cgroup v2 is empty.
+func cgroupPaths(fsys fs.FS) (map[string]string, error) {
+	content, err := fs.ReadFile(fsys, "proc/self/cgroup")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+
+		return nil, fmt.Errorf("read cgroup of process: %w", err)
+	}
+
+	paths := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		// The format is `hierarchy-ID:controller-list:cgroup-path`.
+		fields := strings.SplitN(line, ":", cgroupFields)
+		if len(fields) != cgroupFields {
+			continue
+		}
+		paths[fields[1]] = fields[2]
+		for _, controller := range strings.Split(fields[1], ",") {
+			paths[controller] = fields[2]
+		}
+	}
+
+	return paths, nil
+}
+
+// readFile reads the file in the cgroup path under the mount point.
+// It falls back to the file under the mount point directly, since the container usually
+// has its own cgroup namespace in which the cgroup path is not visible.
+func readFile(fsys fs.FS, mount, path, name string) (string, bool, error) {
+	for _, dir := range []string{strings.TrimSuffix(mount+path, "/"), mount} {
+		content, err := fs.ReadFile(fsys, dir+"/"+name)
+		switch {
+		case err == nil:
+			return strings.TrimSpace(string(content)), true, nil
+		case errors.Is(err, fs.ErrNotExist):
+		default:
+			return "", false, fmt.Errorf("read %s: %w", name, err)
+		}
+	}
+
+	return "", false, nil
+}
+
+func quota(maxQuota, period string) (float64, error) {
+	q, err := strconv.ParseFloat(maxQuota, 64)
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+	p, err := strconv.ParseFloat(period, 64)
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+	if q <= 0 || p <= 0 {
+		return 0, nil
+	}
+
+	return q / p, nil
+}
+
+const (
+	defaultMemoryRatio = 0.9
+	unlimitedMemory    = 1 << 62
+	cgroupFields       = 3
+)
+
+var (
+	errMalformed = errors.New("malformed cgroup file")
+
+	tuned atomic.Pointer[Decision] //nolint:gochecknoglobals
+)
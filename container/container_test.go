@@ -0,0 +1,160 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package container_test
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nil-go/nilgo/container"
+	"github.com/nil-go/nilgo/internal/assert"
+)
+
+//nolint:paralleltest // It changes GOMAXPROCS and GOMEMLIMIT of the process.
+func TestTune(t *testing.T) {
+	procs := runtime.GOMAXPROCS(0)
+	memoryLimit := debug.SetMemoryLimit(-1)
+	t.Cleanup(func() {
+		runtime.GOMAXPROCS(procs)
+		debug.SetMemoryLimit(memoryLimit)
+	})
+
+	testcases := []struct {
+		description string
+		fs          fstest.MapFS
+		env         map[string]string
+		opts        []container.Option
+		expected    container.Decision
+		err         string
+	}{
+		{
+			description: "no cgroup",
+			fs:          fstest.MapFS{},
+			expected: container.Decision{
+				GOMAXPROCS:       procs,
+				GOMAXPROCSSource: "default",
+				GOMEMLIMIT:       memoryLimit,
+				GOMEMLIMITSource: "default",
+			},
+		},
+		{
+			description: "cgroup v2",
+			fs: fstest.MapFS{
+				"proc/self/cgroup":                 {Data: []byte("0::/\n")},
+				"sys/fs/cgroup/cgroup.controllers": {Data: []byte("cpu memory\n")},
+				"sys/fs/cgroup/cpu.max":            {Data: []byte("50000 100000\n")},
+				"sys/fs/cgroup/memory.max":         {Data: []byte("1073741824\n")},
+			},
+			expected: container.Decision{
+				CPUQuota:         0.5,
+				MemoryLimit:      1 << 30,
+				GOMAXPROCS:       1,
+				GOMAXPROCSSource: "cgroup",
+				GOMEMLIMIT:       966367641,
+				GOMEMLIMITSource: "cgroup",
+			},
+		},
+		{
+			description: "cgroup v2 (unlimited)",
+			fs: fstest.MapFS{
+				"proc/self/cgroup":                      {Data: []byte("0::/kubepods/pod\n")},
+				"sys/fs/cgroup/cgroup.controllers":      {Data: []byte("cpu memory\n")},
+				"sys/fs/cgroup/kubepods/pod/cpu.max":    {Data: []byte("max 100000\n")},
+				"sys/fs/cgroup/kubepods/pod/memory.max": {Data: []byte("max\n")},
+			},
+			expected: container.Decision{
+				GOMAXPROCS:       procs,
+				GOMAXPROCSSource: "default",
+				GOMEMLIMIT:       memoryLimit,
+				GOMEMLIMITSource: "default",
+			},
+		},
+		{
+			description: "cgroup v1",
+			fs: fstest.MapFS{
+				"proc/self/cgroup": {Data: []byte(
+					"12:memory:/docker/abc\n4:cpu,cpuacct:/docker/abc\n1:name=systemd:/docker/abc\n",
+				)},
+				"sys/fs/cgroup/cpu,cpuacct/cpu.cfs_quota_us":  {Data: []byte("50000\n")},
+				"sys/fs/cgroup/cpu,cpuacct/cpu.cfs_period_us": {Data: []byte("100000\n")},
+				"sys/fs/cgroup/memory/memory.limit_in_bytes":  {Data: []byte("536870912\n")},
+			},
+			opts: []container.Option{container.WithMemoryRatio(0.5)},
+			expected: container.Decision{
+				CPUQuota:         0.5,
+				MemoryLimit:      1 << 29,
+				GOMAXPROCS:       1,
+				GOMAXPROCSSource: "cgroup",
+				GOMEMLIMIT:       1 << 28,
+				GOMEMLIMITSource: "cgroup",
+			},
+		},
+		{
+			description: "cgroup v1 (unlimited)",
+			fs: fstest.MapFS{
+				"proc/self/cgroup":                           {Data: []byte("12:memory:/\n4:cpu,cpuacct:/\n")},
+				"sys/fs/cgroup/cpu/cpu.cfs_quota_us":         {Data: []byte("-1\n")},
+				"sys/fs/cgroup/cpu/cpu.cfs_period_us":        {Data: []byte("100000\n")},
+				"sys/fs/cgroup/memory/memory.limit_in_bytes": {Data: []byte("9223372036854771712\n")},
+			},
+			expected: container.Decision{
+				GOMAXPROCS:       procs,
+				GOMAXPROCSSource: "default",
+				GOMEMLIMIT:       memoryLimit,
+				GOMEMLIMITSource: "default",
+			},
+		},
+		{
+			description: "env overrides",
+			fs: fstest.MapFS{
+				"proc/self/cgroup":                 {Data: []byte("0::/\n")},
+				"sys/fs/cgroup/cgroup.controllers": {Data: []byte("cpu memory\n")},
+				"sys/fs/cgroup/cpu.max":            {Data: []byte("50000 100000\n")},
+				"sys/fs/cgroup/memory.max":         {Data: []byte("1073741824\n")},
+			},
+			env: map[string]string{"GOMAXPROCS": "4", "GOMEMLIMIT": "1GiB"},
+			expected: container.Decision{
+				CPUQuota:         0.5,
+				MemoryLimit:      1 << 30,
+				GOMAXPROCS:       procs,
+				GOMAXPROCSSource: "env",
+				GOMEMLIMIT:       memoryLimit,
+				GOMEMLIMITSource: "env",
+			},
+		},
+		{
+			description: "malformed",
+			fs: fstest.MapFS{
+				"proc/self/cgroup":                 {Data: []byte("0::/\n")},
+				"sys/fs/cgroup/cgroup.controllers": {Data: []byte("cpu memory\n")},
+				"sys/fs/cgroup/cpu.max":            {Data: []byte("50000\n")},
+			},
+			err: `malformed cgroup file: cpu.max: "50000"`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			runtime.GOMAXPROCS(procs)
+			debug.SetMemoryLimit(memoryLimit)
+			for key, value := range testcase.env {
+				t.Setenv(key, value)
+			}
+
+			decision, err := container.Tune(append(testcase.opts, container.WithFS(testcase.fs))...)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expected, decision)
+			tuned, ok := container.Tuned()
+			assert.Equal(t, true, ok)
+			assert.Equal(t, testcase.expected, tuned)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package container
+
+import "io/fs"
+
+// WithFS provides the file system to read cgroup from, e.g. a fake cgroup file system tree in tests.
+// It reads `proc/self/cgroup` and the files under `sys/fs/cgroup` in it.
+//
+// By default, it's the root directory of the OS.
+func WithFS(fsys fs.FS) Option {
+	return func(options *options) {
+		options.fs = fsys
+	}
+}
+
+// WithMemoryRatio provides the ratio of the container memory limit which is set to GOMEMLIMIT,
+// leaving the rest as headroom for the memory which is not managed by the Go runtime.
+// It should be in (0, 1].
+//
+// By default, it's 0.9.
+func WithMemoryRatio(ratio float64) Option {
+	return func(options *options) {
+		options.memoryRatio = ratio
+	}
+}
+
+type (
+	// Option configures tuning the Go runtime with optional parameters.
+	Option  func(*options)
+	options struct {
+		fs          fs.FS
+		memoryRatio float64
+	}
+)
@@ -0,0 +1,178 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type namedRun struct {
+	name        string
+	run         func(context.Context) error
+	after       []string
+	ready       func(context.Context) error
+	supervision supervision
+	// restartTriggers register the function to restart the run.
+	restartTriggers []func(func()) func()
+}
+
+// sortRuns sorts the named runs in topological order of their dependencies,
+// and keeps the registration order for runs without dependencies between each other.
+//
+// It returns error if there are duplicate names, unknown dependencies or cycles.
+func sortRuns(runs []namedRun) ([]namedRun, error) { //nolint:cyclop
+	indexes := make(map[string]int, len(runs))
+	for i, run := range runs {
+		if _, exist := indexes[run.name]; exist {
+			return nil, fmt.Errorf("duplicate run name %q", run.name) //nolint:err113
+		}
+		indexes[run.name] = i
+	}
+
+	degrees := make([]int, len(runs))
+	dependents := make([][]int, len(runs))
+	for i, run := range runs {
+		for _, dependency := range run.after {
+			index, exist := indexes[dependency]
+			if !exist {
+				return nil, fmt.Errorf("run %q depends on unknown run %q", run.name, dependency) //nolint:err113
+			}
+			degrees[i]++
+			dependents[index] = append(dependents[index], i)
+		}
+	}
+
+	sorted := make([]namedRun, 0, len(runs))
+	visited := make([]bool, len(runs))
+	for len(sorted) < len(runs) {
+		next := -1
+		for i, degree := range degrees {
+			if !visited[i] && degree == 0 {
+				next = i
+
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf("dependency cycle among runs %s", cycle(runs, visited)) //nolint:err113
+		}
+
+		visited[next] = true
+		sorted = append(sorted, runs[next])
+		for _, dependent := range dependents[next] {
+			degrees[dependent]--
+		}
+	}
+
+	return sorted, nil
+}
+
+func cycle(runs []namedRun, visited []bool) string {
+	names := make([]string, 0, len(runs))
+	for i, run := range runs {
+		if !visited[i] {
+			names = append(names, fmt.Sprintf("%q", run.name))
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// orderedRuns converts the sorted named runs into runs which can be executed in parallel.
+//
+// Each run starts after all its dependencies are ready, and its context is canceled
+// only after all runs depending on it have returned, so they stop in reverse order.
+func orderedRuns(runs []namedRun) []namedRun {
+	started := make(map[string]chan struct{}, len(runs))
+	stopped := make(map[string]chan struct{}, len(runs))
+	dependents := make(map[string][]string, len(runs))
+	for _, run := range runs {
+		started[run.name] = make(chan struct{})
+		stopped[run.name] = make(chan struct{})
+		for _, dependency := range run.after {
+			dependents[dependency] = append(dependents[dependency], run.name)
+		}
+	}
+
+	ordered := make([]namedRun, 0, len(runs))
+	for _, run := range runs {
+		ordered = append(ordered, namedRun{name: run.name, run: func(ctx context.Context) error {
+			defer close(stopped[run.name])
+
+			// Wait for all dependencies to be ready.
+			for _, dependency := range run.after {
+				select {
+				case <-started[dependency]:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+
+			// Cancel the run after all dependents have stopped.
+			runCtx, runCancel := context.WithCancel(context.WithoutCancel(ctx))
+			defer runCancel()
+			stop := context.AfterFunc(ctx, func() {
+				for _, dependent := range dependents[run.name] {
+					<-stopped[dependent]
+				}
+				runCancel()
+			})
+			defer stop()
+
+			return run.start(runCtx, started[run.name])
+		}})
+	}
+
+	return ordered
+}
+
+// start executes the run and closes the started channel once the run is ready.
+func (n namedRun) start(ctx context.Context, started chan struct{}) error {
+	if n.ready == nil {
+		close(started)
+
+		return n.run(ctx)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	readyCtx, readyCancel := context.WithCancel(ctx)
+	defer readyCancel()
+
+	var ready bool
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		if err := recovered(readyCtx, n.name, n.ready); err != nil {
+			if readyCtx.Err() == nil {
+				cancel(fmt.Errorf("ready gate: %w", err))
+			}
+
+			return
+		}
+		ready = true
+		close(started)
+	}()
+
+	err := n.run(ctx)
+	readyCancel()
+	<-done
+	if err != nil {
+		return err
+	}
+	if !ready && ctx.Err() == nil {
+		// The run completes before the gate passes, e.g. one-shot migration,
+		// so it's ready for its dependents.
+		close(started)
+	}
+	if err := context.Cause(ctx); err != nil && !errors.Is(err, ctx.Err()) {
+		return err //nolint:wrapcheck
+	}
+
+	return nil
+}
@@ -17,6 +17,9 @@ import (
 
 // Pprof starts a pprof server at localhost:6060.
 // If port 6060 is not available, it will try to find an available port.
+//
+// For production, the admin server in package github.com/nil-go/nilgo/http/admin also serves pprof
+// along with other diagnostics.
 func Pprof(ctx context.Context) error {
 	server := &http.Server{ReadTimeout: time.Second}
 
@@ -0,0 +1,73 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nil-go/nilgo/internal/recovery"
+)
+
+// Phase is the phase of the Runner which the run executes in.
+type Phase string
+
+const (
+	// PhasePreRun is the phase of runs provided by [WithPreRun].
+	PhasePreRun Phase = "pre run"
+	// PhaseStartGate is the phase of gates provided by [WithStartGate].
+	PhaseStartGate Phase = "start gate"
+	// PhaseMainRun is the phase of runs provided by Runner.Run and [Named].
+	PhaseMainRun Phase = "main run"
+	// PhaseStopGate is the phase of gates provided by [WithStopGate].
+	PhaseStopGate Phase = "stop gate"
+	// PhasePostRun is the phase of runs provided by [WithPostRun].
+	PhasePostRun Phase = "post run"
+)
+
+// ErrPanic is wrapped by the error of the run which panics.
+// The panic is recovered by the Runner and starts the shutdown like other failures.
+var ErrPanic = recovery.ErrPanic
+
+// RunError records the error returned by a run with the name and phase of the run.
+//
+// The name is provided by [Named], or the function name for other runs.
+type RunError struct {
+	Phase Phase
+	Name  string
+	Err   error
+}
+
+func (e *RunError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Phase, e.Name, e.Err)
+}
+
+func (e *RunError) Unwrap() error {
+	return e.Err
+}
+
+// Error is returned by Runner.Run if any run fails.
+//
+// It records all failed runs in the order they fail, and supports [errors.Is] and [errors.As]
+// on each of them like the error returned by [errors.Join].
+type Error struct {
+	// Cause is the failure which triggers the shutdown.
+	// It's nil if the shutdown is triggered by OS signals or cancellation on the context.
+	Cause *RunError
+	// Errors are all failures, including the failures during shutdown.
+	Errors []*RunError
+}
+
+func (e *Error) Error() string {
+	return errors.Join(e.Unwrap()...).Error()
+}
+
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
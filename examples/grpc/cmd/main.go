@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/config"
 	"github.com/nil-go/nilgo/dev"
 	"github.com/nil-go/nilgo/gcp/log"
 	"github.com/nil-go/nilgo/gcp/profiler"
@@ -20,7 +21,7 @@ import (
 
 func main() {
 	var (
-		opts []nilgo.Option
+		opts = []nilgo.Option{config.Bootstrap(config.WithEnv())}
 		runs []func(context.Context) error
 	)
 	switch {
@@ -13,16 +13,18 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/config"
 	"github.com/nil-go/nilgo/dev"
 	"github.com/nil-go/nilgo/gcp/log"
 	"github.com/nil-go/nilgo/gcp/profiler"
+	"github.com/nil-go/nilgo/health"
 	nhttp "github.com/nil-go/nilgo/http"
 	"github.com/nil-go/nilgo/otlp"
 )
 
 func main() {
 	var (
-		opts []nilgo.Option
+		opts = []nilgo.Option{config.Bootstrap(config.WithEnv())}
 		runs []func(context.Context) error
 	)
 	switch {
@@ -68,7 +70,7 @@ func main() {
 		ReadTimeout: time.Second,
 	}
 	runs = append(runs,
-		nhttp.Run(server, nhttp.WithConfigService()),
+		nhttp.Run(server, nhttp.WithConfigService(), nhttp.WithHealth(health.Default())),
 	)
 
 	if err := nilgo.New(opts...).Run(context.Background(), runs...); err != nil {
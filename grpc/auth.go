@@ -0,0 +1,123 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/nil-go/nilgo/internal/listener"
+)
+
+// Authorizer authorizes the call to access the config service.
+// It returns error if the access is denied.
+type Authorizer func(context.Context) error
+
+// BearerToken returns an Authorizer which allows the call with any of the given bearer tokens
+// in the `authorization` metadata.
+func BearerToken(tokens ...string) Authorizer {
+	return func(ctx context.Context) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		err := errMissingToken
+		for _, value := range md.Get("authorization") {
+			token, found := strings.CutPrefix(value, "Bearer ")
+			if !found || token == "" {
+				continue
+			}
+			for _, t := range tokens {
+				if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+					return nil
+				}
+			}
+			// Check the other values, since the call may carry multiple `authorization` metadata.
+			err = errInvalidToken
+		}
+
+		return err
+	}
+}
+
+// ClientCertificate returns an Authorizer which allows the call with the verified TLS client certificate
+// whose common name or subject alternative names match any of the given names.
+// It requires the server verifies client certificates, e.g. tls.RequireAndVerifyClientCert.
+func ClientCertificate(names ...string) Authorizer {
+	return func(ctx context.Context) error {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return errMissingCertificate
+		}
+		info, ok := p.AuthInfo.(credentials.TLSInfo)
+		if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+			return errMissingCertificate
+		}
+		if slices.ContainsFunc(certificateNames(info.State.VerifiedChains[0][0]), func(name string) bool {
+			return slices.Contains(names, name)
+		}) {
+			return nil
+		}
+
+		return errUnknownClient
+	}
+}
+
+func certificateNames(certificate *x509.Certificate) []string {
+	names := []string{certificate.Subject.CommonName}
+	names = append(names, certificate.DNSNames...)
+	names = append(names, certificate.EmailAddresses...)
+	for _, uri := range certificate.URIs {
+		names = append(names, uri.String())
+	}
+
+	return names
+}
+
+// guard only allows the authorized calls on the config listeners to access the config service,
+// and logs the denied attempts for audit.
+func guard(authorizer Authorizer, restricted *atomic.Bool) func(context.Context, string) error {
+	return func(ctx context.Context, method string) error {
+		var err error
+		p, _ := peer.FromContext(ctx)
+		if restricted.Load() && (p == nil || !listener.Tagged(p.LocalAddr)) {
+			err = errListener
+		}
+		if err == nil && authorizer != nil {
+			err = authorizer(ctx)
+		}
+		if err == nil {
+			return nil
+		}
+
+		remote := ""
+		if p != nil && p.Addr != nil {
+			remote = p.Addr.String()
+		}
+		slog.LogAttrs(ctx, slog.LevelWarn, "Access to config service is denied.",
+			slog.String("method", method),
+			slog.String("remote", remote),
+			slog.Any("error", err),
+		)
+
+		return status.Error(codes.PermissionDenied, err.Error()) //nolint:wrapcheck
+	}
+}
+
+var (
+	errMissingToken       = errors.New("missing bearer token")
+	errInvalidToken       = errors.New("invalid bearer token")
+	errMissingCertificate = errors.New("missing verified client certificate")
+	errUnknownClient      = errors.New("unknown client certificate")
+	errListener           = errors.New("config service is not served on the listener")
+)
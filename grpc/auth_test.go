@@ -0,0 +1,96 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package grpc_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	ngrpc "github.com/nil-go/nilgo/grpc"
+)
+
+func TestAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	withCertificate := func(name string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: name}}}},
+			}},
+		})
+	}
+
+	testcases := []struct {
+		description string
+		authorizer  ngrpc.Authorizer
+		ctx         context.Context //nolint:containedctx
+		err         string
+	}{
+		{
+			description: "valid token",
+			authorizer:  ngrpc.BearerToken("old", "new"),
+			ctx:         withToken("new"),
+		},
+		{
+			description: "invalid token",
+			authorizer:  ngrpc.BearerToken("old", "new"),
+			ctx:         withToken("other"),
+			err:         "invalid bearer token",
+		},
+		{
+			description: "valid token after invalid token",
+			authorizer:  ngrpc.BearerToken("old", "new"),
+			ctx: metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs("authorization", "Bearer other", "authorization", "Bearer new"),
+			),
+		},
+		{
+			description: "missing token",
+			authorizer:  ngrpc.BearerToken("token"),
+			ctx:         context.Background(),
+			err:         "missing bearer token",
+		},
+		{
+			description: "known client",
+			authorizer:  ngrpc.ClientCertificate("admin"),
+			ctx:         withCertificate("admin"),
+		},
+		{
+			description: "unknown client",
+			authorizer:  ngrpc.ClientCertificate("admin"),
+			ctx:         withCertificate("other"),
+			err:         "unknown client certificate",
+		},
+		{
+			description: "missing certificate",
+			authorizer:  ngrpc.ClientCertificate("admin"),
+			ctx:         context.Background(),
+			err:         "missing verified client certificate",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			err := testcase.authorizer(testcase.ctx)
+			if testcase.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testcase.err)
+			}
+		})
+	}
+}
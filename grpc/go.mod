@@ -4,6 +4,8 @@ go 1.22
 
 require (
 	github.com/nil-go/konf v1.4.0
+	github.com/nil-go/nilgo v0.3.0
+	github.com/nil-go/nilgo/config v0.3.0
 	github.com/nil-go/sloth v0.3.0
 	github.com/stretchr/testify v1.10.0
 	google.golang.org/grpc v1.69.2
@@ -15,7 +17,14 @@ require (
 	github.com/cncf/xds/go v0.0.0-20240905190251-b4127c9b8d78 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/envoyproxy/protoc-gen-validate v1.1.0 // indirect
+	github.com/fsnotify/fsnotify v1.8.0 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/nil-go/konf/provider/file v1.4.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel v1.32.0 // indirect
+	go.opentelemetry.io/otel/metric v1.32.0 // indirect
+	go.opentelemetry.io/otel/trace v1.32.0 // indirect
 	golang.org/x/net v0.31.0 // indirect
 	golang.org/x/oauth2 v0.23.0 // indirect
 	golang.org/x/sys v0.27.0 // indirect
@@ -23,3 +32,11 @@ require (
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20241118233622-e639e219e697 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// The modules in this repository are developed together and tagged with the same version at the same commit,
+// root and config first (see .github/workflows/release.yml). The replacements only apply to the development here,
+// since Go ignores them in dependencies.
+replace (
+	github.com/nil-go/nilgo => ../
+	github.com/nil-go/nilgo/config => ../config
+)
@@ -5,10 +5,17 @@ package internal
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/nil-go/konf"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/nil-go/nilgo/config"
 	pb "github.com/nil-go/nilgo/grpc/pb/nilgo/v1"
 )
 
@@ -16,27 +23,136 @@ import (
 type ConfigServiceServer struct {
 	pb.UnimplementedConfigServiceServer
 
-	configs []*konf.Config
+	configs   []*konf.Config
+	effective *atomic.Pointer[konf.Config]
+	authorize func(context.Context, string) error
+	shutdown  chan struct{}
+	once      sync.Once
 }
 
 // NewConfigServiceServer creates a new ConfigServiceServer with the provided configs.
-func NewConfigServiceServer(configs []*konf.Config) *ConfigServiceServer {
-	return &ConfigServiceServer{configs: configs}
+// The explanation also includes the effective values of the server options if they exist.
+// Each call is authorized by the authorize function with the full method name before it's served.
+func NewConfigServiceServer(
+	configs []*konf.Config,
+	effective *atomic.Pointer[konf.Config],
+	authorize func(context.Context, string) error,
+) *ConfigServiceServer {
+	return &ConfigServiceServer{
+		configs:   configs,
+		effective: effective,
+		authorize: authorize,
+		shutdown:  make(chan struct{}),
+	}
+}
+
+// Shutdown ends all the Watch streams, so the gRPC server can stop gracefully.
+func (c *ConfigServiceServer) Shutdown() {
+	c.once.Do(func() { close(c.shutdown) })
 }
 
-func (c ConfigServiceServer) Explain(_ context.Context, request *pb.ExplainRequest) (*pb.ExplainResponse, error) {
-	if len(c.configs) == 0 {
-		return &pb.ExplainResponse{Explanation: konf.Explain(request.GetPath())}, nil
+func (c *ConfigServiceServer) Explain(ctx context.Context, request *pb.ExplainRequest) (*pb.ExplainResponse, error) {
+	if err := c.authorize(ctx, pb.ConfigService_Explain_FullMethodName); err != nil {
+		return nil, err
 	}
 
 	path := request.GetPath()
-	var explanation strings.Builder
-	for _, config := range c.configs {
-		if explanation.Len() > 0 {
-			explanation.WriteString("\n-----\n")
+	configs := c.explainedConfigs(path)
+	explanations := make([]string, 0, len(configs))
+	for _, cfg := range configs {
+		if cfg == nil {
+			explanations = append(explanations, konf.Explain(path))
+		} else {
+			explanations = append(explanations, cfg.Explain(path))
 		}
-		explanation.WriteString(config.Explain(path))
 	}
 
-	return &pb.ExplainResponse{Explanation: explanation.String()}, nil
+	return &pb.ExplainResponse{Explanation: strings.Join(explanations, "\n-----\n")}, nil
+}
+
+func (c *ConfigServiceServer) ExplainValues(
+	ctx context.Context,
+	request *pb.ExplainValuesRequest,
+) (*pb.ExplainValuesResponse, error) {
+	if err := c.authorize(ctx, pb.ConfigService_ExplainValues_FullMethodName); err != nil {
+		return nil, err
+	}
+
+	path := request.GetPath()
+	var values []config.Value
+	for _, cfg := range c.explainedConfigs(path) {
+		vals, err := config.Values(cfg, path)
+		if err != nil {
+			return nil, valuesError(err)
+		}
+		values = append(values, vals...)
+	}
+
+	return &pb.ExplainValuesResponse{Values: pbValues(values)}, nil
+}
+
+func (c *ConfigServiceServer) Watch(request *pb.WatchRequest, stream pb.ConfigService_WatchServer) error {
+	if err := c.authorize(stream.Context(), pb.ConfigService_Watch_FullMethodName); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.shutdown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := config.WatchValues(ctx, request.GetPath(), func(values []config.Value) error {
+		return stream.Send(&pb.WatchResponse{Values: pbValues(values)}) //nolint:wrapcheck
+	}, c.configs...)
+
+	return valuesError(err)
+}
+
+// valuesError converts config.ErrNotTracked to the status with code FailedPrecondition,
+// since the values can only be resolved from the config created by config.New.
+func valuesError(err error) error {
+	if errors.Is(err, config.ErrNotTracked) {
+		return status.Error(codes.FailedPrecondition, err.Error()) //nolint:wrapcheck
+	}
+
+	return err
+}
+
+func pbValues(values []config.Value) []*pb.Value {
+	pbValues := make([]*pb.Value, 0, len(values))
+	for _, value := range values {
+		overridden := make([]*pb.Override, 0, len(value.Overridden))
+		for _, override := range value.Overridden {
+			overridden = append(overridden, &pb.Override{Value: override.Value, Loader: override.Loader})
+		}
+		pbValues = append(pbValues, &pb.Value{
+			Path:       value.Path,
+			Value:      value.Value,
+			Loader:     value.Loader,
+			Overridden: overridden,
+		})
+	}
+
+	return pbValues
+}
+
+// explainedConfigs returns the configs to explain for the path, in which nil is for the default konf.Config.
+func (c *ConfigServiceServer) explainedConfigs(path string) []*konf.Config {
+	configs := c.configs
+	if len(configs) == 0 {
+		configs = []*konf.Config{nil}
+	}
+	if c.effective == nil {
+		return configs
+	}
+	if cfg := c.effective.Load(); cfg != nil && cfg.Exists(strings.Split(strings.ToLower(path), ".")) {
+		configs = append(slices.Clip(configs), cfg)
+	}
+
+	return configs
 }
@@ -0,0 +1,90 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package internal
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/nil-go/nilgo/grpc/pb/nilgo/v1"
+	"github.com/nil-go/nilgo/log"
+)
+
+// LogServiceServer is an implementation of [pb.LogServiceServer].
+type LogServiceServer struct {
+	pb.UnimplementedLogServiceServer
+
+	control   *log.Control
+	authorize func(context.Context, string) error
+}
+
+// NewLogServiceServer creates a new LogServiceServer with the provided log.Control.
+// Each call is authorized by the authorize function with the full method name before it's served.
+func NewLogServiceServer(control *log.Control, authorize func(context.Context, string) error) *LogServiceServer {
+	return &LogServiceServer{
+		control:   control,
+		authorize: authorize,
+	}
+}
+
+func (l *LogServiceServer) ListLevels(ctx context.Context, _ *pb.ListLevelsRequest) (*pb.ListLevelsResponse, error) {
+	if err := l.authorize(ctx, pb.LogService_ListLevels_FullMethodName); err != nil {
+		return nil, err
+	}
+
+	return &pb.ListLevelsResponse{Levels: l.levels()}, nil
+}
+
+func (l *LogServiceServer) SetLevel(ctx context.Context, request *pb.SetLevelRequest) (*pb.SetLevelResponse, error) {
+	if err := l.authorize(ctx, pb.LogService_SetLevel_FullMethodName); err != nil {
+		return nil, err
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(request.GetLevel())); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+	var ttl time.Duration
+	if request.GetTtl() != nil {
+		if err := request.GetTtl().CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+		}
+		ttl = request.GetTtl().AsDuration()
+	}
+	l.control.Set(request.GetName(), level, ttl)
+
+	return &pb.SetLevelResponse{Levels: l.levels()}, nil
+}
+
+func (l *LogServiceServer) ResetLevel(
+	ctx context.Context,
+	request *pb.ResetLevelRequest,
+) (*pb.ResetLevelResponse, error) {
+	if err := l.authorize(ctx, pb.LogService_ResetLevel_FullMethodName); err != nil {
+		return nil, err
+	}
+
+	l.control.Reset(request.GetName())
+
+	return &pb.ResetLevelResponse{Levels: l.levels()}, nil
+}
+
+func (l *LogServiceServer) levels() []*pb.Level {
+	levels := l.control.Levels()
+	pbLevels := make([]*pb.Level, 0, len(levels))
+	for _, level := range levels {
+		pbLevel := &pb.Level{Name: level.Name, Level: level.Level.String()}
+		if !level.Expiry.IsZero() {
+			pbLevel.Expiry = timestamppb.New(level.Expiry)
+		}
+		pbLevels = append(pbLevels, pbLevel)
+	}
+
+	return pbLevels
+}
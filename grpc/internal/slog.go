@@ -11,7 +11,10 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/nil-go/nilgo/log"
 )
 
 // Slogger is a grpclog.LoggerV2 implementation with slog.Logger.
@@ -19,11 +22,16 @@ import (
 //
 //	grpclog.SetLoggerV2(NewSlogger(handler))
 //
+// The severity from GRPC_GO_LOG_SEVERITY_LEVEL can be changed at runtime
+// by the log level of google.golang.org/grpc in the log.Control (see [Slogger.UseControl]),
+// which is log.Default() by default.
+//
 // To create a new Slogger, use [NewSlogger].
 type Slogger struct {
 	handler   slog.Handler
 	severity  slog.Level
 	verbosity int
+	control   *atomic.Pointer[log.Control]
 }
 
 // NewSlogger creates a new Slogger with the given slog.Handler.
@@ -43,6 +51,17 @@ func NewSlogger(handler slog.Handler) Slogger {
 		handler:   handler,
 		severity:  severity,
 		verbosity: verbosity,
+		control:   &atomic.Pointer[log.Control]{},
+	}
+}
+
+// UseControl changes the log.Control which provides the severity of gRPC logs at runtime.
+// It returns a function to restore log.Default() if the control has not been changed again.
+func (g Slogger) UseControl(control *log.Control) func() {
+	g.control.Store(control)
+
+	return func() {
+		g.control.CompareAndSwap(control, nil)
 	}
 }
 
@@ -115,7 +134,17 @@ func (g Slogger) V(l int) bool {
 }
 
 func (g Slogger) log(depth int, level slog.Level, message string) {
-	if g.severity > level {
+	severity := g.severity
+	control := log.Default()
+	if g.control != nil {
+		if c := g.control.Load(); c != nil {
+			control = c
+		}
+	}
+	if l, ok := control.Level(grpcPackage); ok {
+		severity = l
+	}
+	if severity > level {
 		return
 	}
 
@@ -135,3 +164,5 @@ func (g Slogger) log(depth int, level slog.Level, message string) {
 	// Ignore error: It's fine to lose log.
 	_ = handler.Handle(ctx, slog.NewRecord(time.Now(), level, message, pcs[0]))
 }
+
+const grpcPackage = "google.golang.org/grpc"
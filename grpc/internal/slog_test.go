@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc/grpclog"
 
 	"github.com/nil-go/nilgo/grpc/internal"
+	"github.com/nil-go/nilgo/log"
 )
 
 func TestSlogger(t *testing.T) {
@@ -42,16 +43,65 @@ func TestSlogger(t *testing.T) {
 	grpclog.Errorln("error", " ", "ln")
 	grpclog.Errorf("error %s", "f")
 
-	expected := `level=INFO source=/slog_test.go:35 msg=info
-level=INFO source=/slog_test.go:36 msg="info ln"
-level=INFO source=/slog_test.go:37 msg="info f"
-level=WARN source=/slog_test.go:38 msg=warning
-level=WARN source=/slog_test.go:39 msg="warning ln"
-level=WARN source=/slog_test.go:40 msg="warning f"
-level=ERROR source=/slog_test.go:41 msg=error
-level=ERROR source=/slog_test.go:42 msg="error ln"
-level=ERROR source=/slog_test.go:43 msg="error f"
+	expected := `level=INFO source=/slog_test.go:36 msg=info
+level=INFO source=/slog_test.go:37 msg="info ln"
+level=INFO source=/slog_test.go:38 msg="info f"
+level=WARN source=/slog_test.go:39 msg=warning
+level=WARN source=/slog_test.go:40 msg="warning ln"
+level=WARN source=/slog_test.go:41 msg="warning f"
+level=ERROR source=/slog_test.go:42 msg=error
+level=ERROR source=/slog_test.go:43 msg="error ln"
+level=ERROR source=/slog_test.go:44 msg="error f"
 `
 	pwd, _ := os.Getwd()
 	assert.Equal(t, expected, strings.ReplaceAll(buf.String(), pwd, ""))
 }
+
+func TestSlogger_level(t *testing.T) {
+	t.Setenv("GRPC_GO_LOG_SEVERITY_LEVEL", "error")
+
+	buf := new(bytes.Buffer)
+	logger := internal.NewSlogger(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	}))
+	grpclog.SetLoggerV2(logger)
+	grpclog.Warning("before")
+	log.Set("google.golang.org/grpc", slog.LevelWarn, 0)
+	grpclog.Info("info")
+	grpclog.Warning("warning")
+	log.Reset("google.golang.org/grpc")
+	grpclog.Warning("after")
+
+	assert.Equal(t, "level=WARN msg=warning\n", buf.String())
+}
+
+func TestSlogger_control(t *testing.T) {
+	t.Setenv("GRPC_GO_LOG_SEVERITY_LEVEL", "error")
+
+	buf := new(bytes.Buffer)
+	logger := internal.NewSlogger(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	}))
+	grpclog.SetLoggerV2(logger)
+	control := log.NewControl()
+	restore := logger.UseControl(control)
+	control.Set("google.golang.org/grpc", slog.LevelWarn, 0)
+	grpclog.Info("info")
+	grpclog.Warning("warning")
+	restore()
+	grpclog.Warning("after")
+
+	assert.Equal(t, "level=WARN msg=warning\n", buf.String())
+}
@@ -4,33 +4,49 @@
 package grpc
 
 import (
-	"slices"
-	"strings"
+	"net"
 
 	"github.com/nil-go/konf"
+
+	"github.com/nil-go/nilgo/health"
+	"github.com/nil-go/nilgo/log"
+	"github.com/nil-go/nilgo/socket"
 )
 
 // WithAddress provides the address listened by the gRPC server.
-// It should be either tcp address like `:8080` or unix socket address like `unix:nilgo.sock`.
+// It should be either tcp address like `:8080`, unix socket address like `unix:nilgo.sock`,
+// inherited file descriptor like `fd:3`, or systemd socket activation like `systemd:grpc`.
+// See [socket.Listen] for details.
 //
-// By default, it listens on `localhost:8080`  or `:${PORT}` if the environment variable exists.
+// By default, it listens on the addresses in config `server.grpc.addresses`,
+// or `localhost:8080`  or `:${PORT}` if the environment variable exists.
 func WithAddress(addresses ...string) Option {
 	return func(options *options) {
-		options.addresses = slices.Grow(options.addresses, len(addresses))
-		for _, address := range addresses {
-			network := "tcp"
-			if strings.HasPrefix(address, "unix:") {
-				network = "unix"
-				address = strings.TrimPrefix(address[5:], "//")
-			}
-			options.addresses = append(options.addresses, socket{network: network, address: address})
-		}
+		options.addresses = append(options.addresses, addresses...)
+	}
+}
+
+// WithListener provides the listeners for the gRPC server, which are listened by the caller,
+// e.g. pre-bound listeners in tests.
+func WithListener(listeners ...net.Listener) Option {
+	return func(options *options) {
+		options.listeners = append(options.listeners, listeners...)
+	}
+}
+
+// WithBound provides the handle to report the addresses of the listeners once all of them are bound,
+// e.g. the real port of `localhost:0`. If the health service is registered by the run,
+// it reports after the first evaluation of the health checks, so the status is known by then.
+func WithBound(bound *socket.Bound) Option {
+	return func(options *options) {
+		options.bound = bound
 	}
 }
 
 // WithConfigService registers the pb.ConfigServiceServer implement to the gRPC server.
 //
 // It uses the global konf.Config if the configs are not provided.
+// The explanation also includes the effective values of the server options, e.g. `server.grpc.maxRecvMsgSize`.
 func WithConfigService(configs ...*konf.Config) Option {
 	return func(options *options) {
 		if options.configs == nil {
@@ -40,15 +56,67 @@ func WithConfigService(configs ...*konf.Config) Option {
 	}
 }
 
+// WithLogService registers the pb.LogServiceServer implement to the gRPC server,
+// which changes the log levels in the log.Control at runtime,
+// including the severity of gRPC logs by the level of `google.golang.org/grpc`
+// while the server is running, since gRPC logger is global.
+//
+// It uses log.Default() if the control is nil. The access is gated the same as the config service.
+func WithLogService(control *log.Control) Option {
+	return func(options *options) {
+		if control == nil {
+			control = log.Default()
+		}
+		options.logControl = control
+	}
+}
+
+// WithHealth provides the health registry which the health service reports on.
+// The status of each gRPC service is derived from the readiness checks
+// which apply to the service (see [health.WithServices]). The checks are evaluated while serving,
+// and the services are not serving until the first evaluation passes.
+//
+// By default, it uses health.Default().
+func WithHealth(registry *health.Registry) Option {
+	return func(options *options) {
+		options.health = registry
+	}
+}
+
+// WithConfigAuthorizer provides the Authorizer to gate the access to the config service and log service,
+// e.g. BearerToken, ClientCertificate or a custom function. The denied attempts are logged for audit.
+//
+// By default, the config service and log service are accessible to any caller.
+func WithConfigAuthorizer(authorizer Authorizer) Option {
+	return func(options *options) {
+		options.configAuthorizer = authorizer
+	}
+}
+
+// WithConfigAddress provides the addresses which the gRPC server also listens on,
+// e.g. unix socket address or admin port, and restricts the config service and log service
+// to the listeners of them.
+//
+// By default, the config service and log service are served on all listeners.
+func WithConfigAddress(addresses ...string) Option {
+	return func(options *options) {
+		options.configAddresses = append(options.configAddresses, addresses...)
+	}
+}
+
 type (
 	// Option configures the runner for the gRPC server.
 	Option  func(*options)
 	options struct {
-		addresses []socket
+		addresses []string
+		listeners []net.Listener
+		bound     *socket.Bound
 		configs   []*konf.Config
-	}
-	socket struct {
-		network string
-		address string
+		health    *health.Registry
+
+		logControl *log.Control
+
+		configAuthorizer Authorizer
+		configAddresses  []string
 	}
 )
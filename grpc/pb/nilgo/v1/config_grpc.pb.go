@@ -20,7 +20,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ConfigService_Explain_FullMethodName = "/nilgo.v1.ConfigService/Explain"
+	ConfigService_Explain_FullMethodName       = "/nilgo.v1.ConfigService/Explain"
+	ConfigService_ExplainValues_FullMethodName = "/nilgo.v1.ConfigService/ExplainValues"
+	ConfigService_Watch_FullMethodName         = "/nilgo.v1.ConfigService/Watch"
 )
 
 // ConfigServiceClient is the client API for ConfigService service.
@@ -29,6 +31,12 @@ const (
 type ConfigServiceClient interface {
 	// Explain returns the detail information about how Config resolve each value from loaders for the given path.
 	Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error)
+	// ExplainValues returns the resolved value of each key under the given path,
+	// along with the loader which provides it and the loaders which are overridden.
+	ExplainValues(ctx context.Context, in *ExplainValuesRequest, opts ...grpc.CallOption) (*ExplainValuesResponse, error)
+	// Watch streams the resolved values of keys under the given path at first,
+	// and then each time the values change, e.g. to confirm the config rollout.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ConfigService_WatchClient, error)
 }
 
 type configServiceClient struct {
@@ -48,12 +56,59 @@ func (c *configServiceClient) Explain(ctx context.Context, in *ExplainRequest, o
 	return out, nil
 }
 
+func (c *configServiceClient) ExplainValues(ctx context.Context, in *ExplainValuesRequest, opts ...grpc.CallOption) (*ExplainValuesResponse, error) {
+	out := new(ExplainValuesResponse)
+	err := c.cc.Invoke(ctx, ConfigService_ExplainValues_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ConfigService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ConfigService_ServiceDesc.Streams[0], ConfigService_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &configServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ConfigService_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type configServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *configServiceWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ConfigServiceServer is the server API for ConfigService service.
 // All implementations must embed UnimplementedConfigServiceServer
 // for forward compatibility
 type ConfigServiceServer interface {
 	// Explain returns the detail information about how Config resolve each value from loaders for the given path.
 	Explain(context.Context, *ExplainRequest) (*ExplainResponse, error)
+	// ExplainValues returns the resolved value of each key under the given path,
+	// along with the loader which provides it and the loaders which are overridden.
+	ExplainValues(context.Context, *ExplainValuesRequest) (*ExplainValuesResponse, error)
+	// Watch streams the resolved values of keys under the given path at first,
+	// and then each time the values change, e.g. to confirm the config rollout.
+	Watch(*WatchRequest, ConfigService_WatchServer) error
 	mustEmbedUnimplementedConfigServiceServer()
 }
 
@@ -64,6 +119,12 @@ type UnimplementedConfigServiceServer struct {
 func (UnimplementedConfigServiceServer) Explain(context.Context, *ExplainRequest) (*ExplainResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Explain not implemented")
 }
+func (UnimplementedConfigServiceServer) ExplainValues(context.Context, *ExplainValuesRequest) (*ExplainValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainValues not implemented")
+}
+func (UnimplementedConfigServiceServer) Watch(*WatchRequest, ConfigService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedConfigServiceServer) mustEmbedUnimplementedConfigServiceServer() {}
 
 // UnsafeConfigServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -95,6 +156,45 @@ func _ConfigService_Explain_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConfigService_ExplainValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).ExplainValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_ExplainValues_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).ExplainValues(ctx, req.(*ExplainValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConfigServiceServer).Watch(m, &configServiceWatchServer{stream})
+}
+
+type ConfigService_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type configServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *configServiceWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ConfigService_ServiceDesc is the grpc.ServiceDesc for ConfigService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -106,7 +206,17 @@ var ConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Explain",
 			Handler:    _ConfigService_Explain_Handler,
 		},
+		{
+			MethodName: "ExplainValues",
+			Handler:    _ConfigService_ExplainValues_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _ConfigService_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "nilgo/v1/config.proto",
 }
@@ -13,25 +13,67 @@ import (
 	"log/slog"
 	"net"
 	"os"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/nil-go/konf"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/reflection/grpc_reflection_v1"
 
+	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/config"
 	"github.com/nil-go/nilgo/grpc/internal"
 	pb "github.com/nil-go/nilgo/grpc/pb/nilgo/v1"
+	nilhealth "github.com/nil-go/nilgo/health"
+	nileffective "github.com/nil-go/nilgo/internal/effective"
+	"github.com/nil-go/nilgo/internal/listener"
+	"github.com/nil-go/nilgo/socket"
 )
 
 // NewServer creates a new gRPC server with the given options.
 //
 // It wraps grpc.NewServer with built-in interceptors, e.g recovery, log buffering.
+// It also applies the message size limits in config `server.grpc.maxRecvMsgSize` and `server.grpc.maxSendMsgSize`
+// from the global konf.Config at the time it's called, while the explicit options take precedence.
+// It applies the keepalive policy in config `server.grpc.keepalive` as well,
+// i.e. `time`, `timeout`, `minTime` and `permitWithoutStream` (see keepalive.ServerParameters
+// and keepalive.EnforcementPolicy).
+//
+// Since gRPC fixes the limits and keepalive policy once the server is created, it should be called
+// after the config has been loaded, e.g. in the main run if the config is loaded by
+// github.com/nil-go/nilgo/config.Bootstrap. They can not be changed in place on the created server.
 func NewServer(opts ...grpc.ServerOption) *grpc.Server {
 	handler := slog.Default().Handler()
 	builtInOpts := []grpc.ServerOption{grpc.WaitForHandlers(true)}
+	var cfg serverConfig
+	if err := konf.Unmarshal(configPath, &cfg); err != nil {
+		slog.LogAttrs(context.Background(), slog.LevelWarn, "Fail to load config of gRPC Server.", slog.Any("error", err))
+	}
+	if cfg.MaxRecvMsgSize > 0 {
+		builtInOpts = append(builtInOpts, grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize))
+	}
+	if cfg.MaxSendMsgSize > 0 {
+		builtInOpts = append(builtInOpts, grpc.MaxSendMsgSize(cfg.MaxSendMsgSize))
+	}
+	if cfg.Keepalive.Time > 0 || cfg.Keepalive.Timeout > 0 {
+		builtInOpts = append(builtInOpts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    cfg.Keepalive.Time,
+			Timeout: cfg.Keepalive.Timeout,
+		}))
+	}
+	if cfg.Keepalive.MinTime > 0 || cfg.Keepalive.PermitWithoutStream {
+		builtInOpts = append(builtInOpts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.Keepalive.MinTime,
+			PermitWithoutStream: cfg.Keepalive.PermitWithoutStream,
+		}))
+	}
 	if internal.IsSamplingHandler(handler) {
 		builtInOpts = append(builtInOpts,
 			grpc.ChainUnaryInterceptor(internal.BufferUnaryInterceptor),
@@ -46,50 +88,149 @@ func NewServer(opts ...grpc.ServerOption) *grpc.Server {
 	)
 	builtInOpts = append(builtInOpts, opts...)
 
-	return grpc.NewServer(builtInOpts...)
+	server := grpc.NewServer(builtInOpts...)
+	appliedConfigs.Store(server, cfg)
+
+	return server
 }
 
 // Run wraps start/stop of the gRPC server in a single run function
 // with listening on multiple tcp and unix socket address.
 //
 // It also resister health and reflection services if the services have not registered.
-func Run(server *grpc.Server, opts ...Option) func(context.Context) error { //nolint:cyclop,funlen,gocognit
+// The status of each service is derived from the readiness checks in the health registry
+// (see [WithHealth]), and it's refreshed periodically.
+//
+// The run can not restart, e.g. by config.RestartOnChange or restart policies of named runs,
+// since grpc.Server can not serve again after stop. Use [RunNew] for the run which restarts.
+func Run(server *grpc.Server, opts ...Option) func(context.Context) error {
 	if server == nil {
 		server = grpc.NewServer()
 	}
 
+	var executed atomic.Bool
+
+	return run(func() (*grpc.Server, error) {
+		if executed.Swap(true) {
+			return nil, errRestart
+		}
+
+		return server, nil
+	}, opts...)
+}
+
+// RunNew is as same as [Run], except that it creates the gRPC server by newServer for each execution of the run,
+// so the run can restart, e.g. by config.RestartOnChange or restart policies of named runs.
+// The server created by [NewServer] applies the config at the time, e.g. keepalive policy.
+func RunNew(newServer func() *grpc.Server, opts ...Option) func(context.Context) error {
+	return run(func() (*grpc.Server, error) {
+		if server := newServer(); server != nil {
+			return server, nil
+		}
+
+		return grpc.NewServer(), nil
+	}, opts...)
+}
+
+func run( //nolint:cyclop,funlen,gocognit
+	newServer func() (*grpc.Server, error), opts ...Option,
+) func(context.Context) error {
 	option := &options{}
 	for _, opt := range opts {
 		opt(option)
 	}
-	if len(option.addresses) == 0 {
-		address := "localhost:8080"
-		if a := os.Getenv("PORT"); a != "" {
-			address = ":" + a
-		}
-		option.addresses = []socket{{network: "tcp", address: address}}
+	if option.health == nil {
+		option.health = nilhealth.Default()
 	}
 
-	// Register config service if necessary.
-	if option.configs != nil {
-		pb.RegisterConfigServiceServer(server, internal.NewConfigServiceServer(option.configs))
-	}
-	// Register reflection service if necessary.
-	if _, exist := server.GetServiceInfo()[grpc_reflection_v1.ServerReflection_ServiceDesc.ServiceName]; !exist {
-		reflection.Register(server)
-	}
-	// Register health service if necessary.
-	var healthServer *health.Server
-	if _, exist := server.GetServiceInfo()[grpc_health_v1.Health_ServiceDesc.ServiceName]; !exist {
-		healthServer = health.NewServer()
-		defer healthServer.Resume()
-		grpc_health_v1.RegisterHealthServer(server, healthServer)
-	}
+	var (
+		effective atomic.Pointer[konf.Config]
+		// restricted is true if the config service is only served on the config listeners.
+		restricted atomic.Bool
+	)
 
 	return func(ctx context.Context) error {
+		server, err := newServer()
+		if err != nil {
+			return err
+		}
+		configServer, healthServer := register(server, option, &effective, &restricted)
+		// Take the config applied by NewServer, so the record does not outlive the server.
+		applied, _ := appliedConfigs.LoadAndDelete(server)
+
 		ctx, cancel := context.WithCancelCause(ctx)
 		defer cancel(nil)
 
+		// The severity of gRPC logs follows the control of the log service while the server is running,
+		// as gRPC logger is global.
+		if option.logControl != nil {
+			defer slogger.UseControl(option.logControl)()
+		}
+
+		// Resolve options from config, while explicit options take precedence.
+		var cfg serverConfig
+		if err := konf.Unmarshal(configPath, &cfg); err != nil {
+			return fmt.Errorf("load config of gRPC Server: %w", err)
+		}
+		addresses := option.addresses
+		if len(addresses) == 0 && len(option.listeners) == 0 {
+			addresses = cfg.Addresses
+		}
+		if len(addresses) == 0 && len(option.listeners) == 0 {
+			address := "localhost:8080"
+			if a := os.Getenv("PORT"); a != "" {
+				address = ":" + a
+			}
+			addresses = []string{address}
+		}
+		values := map[string]any{"addresses": addresses}
+		// Only the options applied by NewServer are known, as the explicit options are opaque.
+		if applied, ok := applied.(serverConfig); ok {
+			applied.effective(values)
+		}
+		// The effective values of options are explained along with the configuration, including by config.Values.
+		effectiveCfg, _ := config.New(config.WithLoader(nileffective.Loader{ // It never fails.
+			Name:   "grpc.Run",
+			Values: map[string]any{"server": map[string]any{"grpc": values}},
+		}))
+		effective.Store(effectiveCfg)
+		defer func() { config.Release(effective.Load()) }()
+
+		if runner, ok := nilgo.FromContext(ctx); ok && healthServer != nil {
+			// Shutdown health server as soon as the runner starts stopping,
+			// so client knows it's not serving while waiting for stop gates.
+			defer runner.Subscribe(func(event nilgo.Event) {
+				if event.Kind == nilgo.EventStateChanged && event.State == nilgo.StateStopping {
+					healthServer.Shutdown()
+				}
+			})()
+		}
+		// evaluated is closed after the first evaluation of the health checks.
+		evaluated := make(chan struct{})
+		if healthServer != nil {
+			// The services are not serving until the checks pass,
+			// while the checks are evaluated along with serving, so the slow checks do not delay the start.
+			healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+			for service := range server.GetServiceInfo() {
+				healthServer.SetServingStatus(service, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+			}
+			go func() {
+				updateHealth(ctx, server, healthServer, option.health)
+				close(evaluated)
+
+				ticker := time.NewTicker(healthInterval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						updateHealth(ctx, server, healthServer, option.health)
+					}
+				}
+			}()
+		}
 		defer context.AfterFunc(ctx, func() {
 			slog.LogAttrs(ctx, slog.LevelInfo, "Starting shutdown gRPC Server...")
 			if healthServer != nil {
@@ -98,28 +239,46 @@ func Run(server *grpc.Server, opts ...Option) func(context.Context) error { //no
 				healthServer.Shutdown()
 				slog.LogAttrs(ctx, slog.LevelInfo, "Shutdown gRPC Health service completed.")
 			}
+			if configServer != nil {
+				// Shutdown config service so the watch streams do not block graceful stop.
+				configServer.Shutdown()
+			}
 			server.GracefulStop()
 			slog.LogAttrs(ctx, slog.LevelInfo, "Shutdown gRPC Server completed.")
 		})()
 
 		slog.LogAttrs(ctx, slog.LevelInfo, "Starting gRPC Server...")
-		var waitGroup sync.WaitGroup
-		waitGroup.Add(len(option.addresses))
-		for _, addr := range option.addresses {
-			go func() {
-				defer waitGroup.Done()
+		listeners := slices.Clone(option.listeners)
+		for _, address := range addresses {
+			ls, err := socket.Listen(address)
+			if err != nil {
+				cancel(fmt.Errorf("start listener: %w", err))
 
-				if addr.network == "unix" {
-					if err := os.RemoveAll(addr.address); err != nil {
-						slog.LogAttrs(ctx, slog.LevelWarn, "Could not delete unix socket file.", slog.Any("error", err))
-					}
-				}
-				listener, err := net.Listen(addr.network, addr.address)
+				break
+			}
+			listeners = append(listeners, ls...)
+		}
+		if len(option.configAddresses) > 0 {
+			restricted.Store(true)
+			for _, address := range option.configAddresses {
+				ls, err := socket.Listen(address)
 				if err != nil {
-					cancel(fmt.Errorf("start listener: %w", err))
+					cancel(fmt.Errorf("start config listener: %w", err))
 
-					return
+					break
+				}
+				for _, l := range ls {
+					// Tag the connections from config listeners, since the local address of connection
+					// may not match the address of listener, e.g. wildcard address.
+					listeners = append(listeners, listener.Tag(l))
 				}
+			}
+		}
+		var waitGroup sync.WaitGroup
+		waitGroup.Add(len(listeners))
+		for _, listener := range listeners {
+			go func() {
+				defer waitGroup.Done()
 
 				slog.LogAttrs(ctx, slog.LevelInfo, fmt.Sprintf("gRPC Server listens on %s.", listener.Addr()))
 				if err := server.Serve(listener); err != nil {
@@ -127,6 +286,23 @@ func Run(server *grpc.Server, opts ...Option) func(context.Context) error { //no
 				}
 			}()
 		}
+		if option.bound != nil {
+			// Report the addresses once the health status is evaluated,
+			// so the dependents waiting for the bound see the status of the checks.
+			if healthServer != nil {
+				select {
+				case <-ctx.Done():
+				case <-evaluated:
+				}
+			}
+			if context.Cause(ctx) == nil {
+				addrs := make([]net.Addr, 0, len(listeners))
+				for _, listener := range listeners {
+					addrs = append(addrs, listener.Addr())
+				}
+				option.bound.Set(addrs)
+			}
+		}
 		waitGroup.Wait()
 
 		if err := context.Cause(ctx); err != nil && !errors.Is(err, ctx.Err()) {
@@ -137,7 +313,110 @@ func Run(server *grpc.Server, opts ...Option) func(context.Context) error { //no
 	}
 }
 
+// register registers the built-in services to the server if necessary.
+func register(
+	server *grpc.Server,
+	option *options,
+	effective *atomic.Pointer[konf.Config],
+	restricted *atomic.Bool,
+) (configServer *internal.ConfigServiceServer, healthServer *health.Server) {
+	// Register config service if necessary.
+	if option.configs != nil {
+		configServer = internal.NewConfigServiceServer(
+			option.configs, effective, guard(option.configAuthorizer, restricted),
+		)
+		pb.RegisterConfigServiceServer(server, configServer)
+	}
+	// Register log service if necessary.
+	if option.logControl != nil {
+		pb.RegisterLogServiceServer(server, internal.NewLogServiceServer(
+			option.logControl, guard(option.configAuthorizer, restricted),
+		))
+	}
+	// Register reflection service if necessary.
+	if _, exist := server.GetServiceInfo()[grpc_reflection_v1.ServerReflection_ServiceDesc.ServiceName]; !exist {
+		reflection.Register(server)
+	}
+	// Register health service if necessary.
+	if _, exist := server.GetServiceInfo()[grpc_health_v1.Health_ServiceDesc.ServiceName]; !exist {
+		healthServer = health.NewServer()
+		defer healthServer.Resume()
+		grpc_health_v1.RegisterHealthServer(server, healthServer)
+	}
+
+	return configServer, healthServer
+}
+
+const healthInterval = 5 * time.Second
+
+func updateHealth(ctx context.Context, server *grpc.Server, healthServer *health.Server, registry *nilhealth.Registry) {
+	servingStatus := func(status nilhealth.Status) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		if status == nilhealth.StatusUp {
+			return grpc_health_v1.HealthCheckResponse_SERVING
+		}
+
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+
+	// Evaluate all checks once, and derive the status of each service from the report.
+	report := registry.Check(ctx, nilhealth.Readiness)
+	healthServer.SetServingStatus("", servingStatus(report.Status))
+	for service := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(service, servingStatus(report.Service(service)))
+	}
+}
+
+type serverConfig struct {
+	Addresses      []string
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+	Keepalive      struct {
+		Time                time.Duration
+		Timeout             time.Duration
+		MinTime             time.Duration
+		PermitWithoutStream bool
+	}
+}
+
+// effective adds the values applied by NewServer into the effective values.
+func (c serverConfig) effective(values map[string]any) {
+	if c.MaxRecvMsgSize > 0 {
+		values["maxRecvMsgSize"] = c.MaxRecvMsgSize
+	}
+	if c.MaxSendMsgSize > 0 {
+		values["maxSendMsgSize"] = c.MaxSendMsgSize
+	}
+	keepalive := make(map[string]any)
+	if c.Keepalive.Time > 0 {
+		keepalive["time"] = c.Keepalive.Time
+	}
+	if c.Keepalive.Timeout > 0 {
+		keepalive["timeout"] = c.Keepalive.Timeout
+	}
+	if c.Keepalive.MinTime > 0 {
+		keepalive["minTime"] = c.Keepalive.MinTime
+	}
+	if c.Keepalive.PermitWithoutStream {
+		keepalive["permitWithoutStream"] = true
+	}
+	if len(keepalive) > 0 {
+		values["keepalive"] = keepalive
+	}
+}
+
+const configPath = "server.grpc"
+
+var errRestart = errors.New("gRPC server can not serve again after stop, use grpc.RunNew for the run which restarts")
+
+//nolint:gochecknoglobals
+var (
+	// appliedConfigs records the config applied by NewServer for each gRPC server.
+	appliedConfigs sync.Map
+	// slogger is the grpclog.LoggerV2 which redirects gRPC log to slog.
+	slogger = internal.NewSlogger(slog.Default().Handler())
+)
+
 func init() { //nolint:gochecknoinits
 	// Redirect gRPC log to slog.
-	grpclog.SetLoggerV2(internal.NewSlogger(slog.Default().Handler()))
+	grpclog.SetLoggerV2(slogger)
 }
@@ -5,22 +5,39 @@ package grpc_test
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/nil-go/konf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/interop"
 	"google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
+	"github.com/nil-go/nilgo"
+	nconfig "github.com/nil-go/nilgo/config"
 	ngrpc "github.com/nil-go/nilgo/grpc"
 	pb "github.com/nil-go/nilgo/grpc/pb/nilgo/v1"
+	nilhealth "github.com/nil-go/nilgo/health"
+	"github.com/nil-go/nilgo/log"
+	"github.com/nil-go/nilgo/socket"
 )
 
 func TestRun(t *testing.T) {
@@ -121,10 +138,7 @@ func TestRun(t *testing.T) {
 			)
 			require.NoError(t, err)
 
-			hcClient := grpc_health_v1.NewHealthClient(conn)
-			hcResp, err := hcClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
-			require.NoError(t, err)
-			require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, hcResp.GetStatus())
+			require.NoError(t, waitServing(ctx, grpc_health_v1.NewHealthClient(conn), ""))
 
 			refClient := grpc_reflection_v1.NewServerReflectionClient(conn)
 			stream, err := refClient.ServerReflectionInfo(ctx, grpc.WaitForReady(true))
@@ -138,8 +152,527 @@ func TestRun(t *testing.T) {
 	}
 }
 
+//nolint:paralleltest // It changes the default config.
+func TestRun_config(t *testing.T) {
+	endpoint := t.TempDir() + "/test.sock"
+	config, err := nconfig.New(nconfig.WithLoader(mapLoader{
+		"server": map[string]any{
+			"grpc": map[string]any{
+				"addresses":      "unix://" + endpoint,
+				"maxRecvMsgSize": 1024,
+				"maxSendMsgSize": 2048,
+				"keepalive":      map[string]any{"time": "1m"},
+			},
+		},
+	}))
+	require.NoError(t, err)
+	nconfig.SetDefault(config)
+	defer nconfig.SetDefault(konf.New())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- ngrpc.Run(
+			ngrpc.NewServer(),
+			ngrpc.WithConfigService(),
+			ngrpc.WithHealth(nilhealth.New()),
+		)(ctx)
+	}()
+
+	conn, err := grpc.NewClient("unix://"+endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	client := pb.NewConfigServiceClient(conn)
+	resp, err := client.Explain(ctx, &pb.ExplainRequest{Path: "server.grpc.maxRecvMsgSize"}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	assert.Equal(t, "server.grpc.maxRecvMsgSize has value[1024] that is loaded by loader[map].\n\n"+
+		"\n-----\n"+
+		"server.grpc.maxRecvMsgSize has value[1024] that is loaded by loader[grpc.Run].\n\n",
+		resp.GetExplanation(),
+	)
+	resp, err = client.Explain(ctx, &pb.ExplainRequest{Path: "server.grpc.maxSendMsgSize"})
+	require.NoError(t, err)
+	assert.Equal(t, "server.grpc.maxSendMsgSize has value[2048] that is loaded by loader[map].\n\n"+
+		"\n-----\n"+
+		"server.grpc.maxSendMsgSize has value[2048] that is loaded by loader[grpc.Run].\n\n",
+		resp.GetExplanation(),
+	)
+
+	resp, err = client.Explain(ctx, &pb.ExplainRequest{Path: "server.grpc.keepalive.time"})
+	require.NoError(t, err)
+	assert.Equal(t, "server.grpc.keepalive.time has value[1m] that is loaded by loader[map].\n\n"+
+		"\n-----\n"+
+		"server.grpc.keepalive.time has value[1m0s] that is loaded by loader[grpc.Run].\n\n",
+		resp.GetExplanation(),
+	)
+
+	values, err := client.ExplainValues(ctx, &pb.ExplainValuesRequest{Path: "server.grpc.maxRecvMsgSize"})
+	require.NoError(t, err)
+	require.Len(t, values.GetValues(), 2)
+	assert.Equal(t, "map", values.GetValues()[0].GetLoader())
+	assert.Equal(t, "1024", values.GetValues()[0].GetValue())
+	assert.Equal(t, "grpc.Run", values.GetValues()[1].GetLoader())
+	assert.Equal(t, "server.grpc.maxRecvMsgSize", values.GetValues()[1].GetPath())
+
+	watch, err := client.Watch(ctx, &pb.WatchRequest{Path: "server.grpc.addresses"})
+	require.NoError(t, err)
+	watched, err := watch.Recv()
+	require.NoError(t, err)
+	require.Len(t, watched.GetValues(), 1)
+	assert.Equal(t, "unix://"+endpoint, watched.GetValues()[0].GetValue())
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestRun_configAccess(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	public, admin := "unix://"+dir+"/public.sock", "unix://"+dir+"/admin.sock"
+	var bound socket.Bound
+	done := make(chan error, 1)
+	go func() {
+		done <- ngrpc.Run(ngrpc.NewServer(),
+			ngrpc.WithAddress(public),
+			ngrpc.WithBound(&bound),
+			ngrpc.WithConfigService(konf.New()),
+			ngrpc.WithConfigAddress(admin),
+			ngrpc.WithConfigAuthorizer(ngrpc.BearerToken("token")),
+			ngrpc.WithHealth(nilhealth.New()),
+		)(ctx)
+	}()
+	_, err := bound.Addrs(ctx)
+	require.NoError(t, err)
+
+	for _, testcase := range []struct {
+		endpoint string
+		token    string
+		err      string
+	}{
+		{
+			endpoint: public,
+			token:    "token",
+			err:      "rpc error: code = PermissionDenied desc = config service is not served on the listener",
+		},
+		{
+			endpoint: admin,
+			err:      "rpc error: code = PermissionDenied desc = missing bearer token",
+		},
+		{
+			endpoint: admin,
+			token:    "token",
+		},
+	} {
+		conn, err := grpc.NewClient(testcase.endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		callCtx := ctx
+		if testcase.token != "" {
+			callCtx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+testcase.token)
+		}
+		_, err = pb.NewConfigServiceClient(conn).Explain(callCtx, &pb.ExplainRequest{Path: "app"})
+		if testcase.err == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, testcase.err)
+		}
+		require.NoError(t, conn.Close())
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestRun_configAccess_wildcard(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var bound socket.Bound
+	done := make(chan error, 1)
+	go func() {
+		done <- ngrpc.Run(ngrpc.NewServer(),
+			ngrpc.WithAddress("localhost:0"),
+			ngrpc.WithBound(&bound),
+			ngrpc.WithConfigService(konf.New()),
+			ngrpc.WithConfigAddress(":0"), // Wildcard address.
+			ngrpc.WithHealth(nilhealth.New()),
+		)(ctx)
+	}()
+	addrs, err := bound.Addrs(ctx)
+	require.NoError(t, err)
+	require.Len(t, addrs, 2)
+	admin, ok := addrs[1].(*net.TCPAddr)
+	require.True(t, ok)
+
+	for _, testcase := range []struct {
+		endpoint string
+		err      string
+	}{
+		{
+			endpoint: addrs[0].String(),
+			err:      "rpc error: code = PermissionDenied desc = config service is not served on the listener",
+		},
+		{
+			endpoint: "localhost:" + strconv.Itoa(admin.Port),
+		},
+	} {
+		conn, err := grpc.NewClient(testcase.endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		_, err = pb.NewConfigServiceClient(conn).Explain(ctx, &pb.ExplainRequest{Path: "app"})
+		if testcase.err == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, testcase.err)
+		}
+		require.NoError(t, conn.Close())
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+//nolint:paralleltest // It changes the default config.
+func TestRun_bootstrap(t *testing.T) {
+	endpoint := t.TempDir() + "/test.sock"
+	runner := nilgo.New(nconfig.Bootstrap(nconfig.WithLoader(mapLoader{
+		"server": map[string]any{
+			"grpc": map[string]any{
+				"addresses":      "unix://" + endpoint,
+				"maxRecvMsgSize": 64,
+			},
+		},
+	})))
+	defer nconfig.SetDefault(konf.New())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- runner.Run(ctx, func(ctx context.Context) error {
+			// Create the server after the config has been loaded by the start gate.
+			return ngrpc.Run(ngrpc.NewServer(), ngrpc.WithConfigService())(ctx)
+		})
+	}()
+
+	conn, err := grpc.NewClient("unix://"+endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	client := pb.NewConfigServiceClient(conn)
+	_, err = client.Explain(ctx, &pb.ExplainRequest{Path: "server.grpc"}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	_, err = client.Explain(ctx, &pb.ExplainRequest{Path: strings.Repeat("server.grpc.", 10)})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+type mapLoader map[string]any
+
+func (m mapLoader) Load() (map[string]any, error) {
+	return m, nil
+}
+
+func (m mapLoader) String() string {
+	return "map"
+}
+
 type panicServer struct{ grpc_testing.TestServiceServer }
 
 func (s panicServer) UnimplementedCall(context.Context, *grpc_testing.Empty) (*grpc_testing.Empty, error) {
 	panic("unimplemented panic")
 }
+
+func TestRun_runner(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	endpoint := t.TempDir() + "/test.sock"
+	conn, err := grpc.NewClient("unix://"+endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	var status grpc_health_v1.HealthCheckResponse_ServingStatus
+	runner := nilgo.New(nilgo.WithStopGate(func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		status = resp.GetStatus()
+
+		return err
+	}))
+	err = runner.Run(ctx,
+		ngrpc.Run(ngrpc.NewServer(), ngrpc.WithAddress("unix://"+endpoint)),
+		func(ctx context.Context) error {
+			require.NoError(t, waitServing(ctx, client, ""))
+			cancel()
+
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status)
+}
+
+func TestRun_health(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	endpoint := t.TempDir() + "/test.sock"
+	conn, err := grpc.NewClient("unix://"+endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	registry := nilhealth.New()
+	registry.Register("db", func(context.Context) error { return errors.New("connection refused") },
+		nilhealth.WithServices(grpc_testing.TestService_ServiceDesc.ServiceName))
+	server := ngrpc.NewServer()
+	grpc_testing.RegisterTestServiceServer(server, panicServer{})
+
+	run := ngrpc.Run(server, ngrpc.WithAddress("unix://"+endpoint), ngrpc.WithHealth(registry))
+	done := make(chan error, 1)
+	go func() { done <- run(ctx) }()
+
+	// The reflection service is serving once the checks are evaluated.
+	require.NoError(t, waitServing(ctx, client, grpc_reflection_v1.ServerReflection_ServiceDesc.ServiceName))
+	testcases := []struct {
+		service  string
+		expected grpc_health_v1.HealthCheckResponse_ServingStatus
+	}{
+		{service: "", expected: grpc_health_v1.HealthCheckResponse_NOT_SERVING},
+		{
+			service:  grpc_testing.TestService_ServiceDesc.ServiceName,
+			expected: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			service:  grpc_reflection_v1.ServerReflection_ServiceDesc.ServiceName,
+			expected: grpc_health_v1.HealthCheckResponse_SERVING,
+		},
+	}
+	for _, testcase := range testcases {
+		resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: testcase.service}, grpc.WaitForReady(true))
+		require.NoError(t, err)
+		assert.Equal(t, testcase.expected, resp.GetStatus(), testcase.service)
+	}
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestRun_health_slow(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	endpoint := t.TempDir() + "/test.sock"
+	conn, err := grpc.NewClient("unix://"+endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	var checked atomic.Int32
+	release := make(chan struct{})
+	registry := nilhealth.New()
+	registry.Register("slow", func(context.Context) error {
+		checked.Add(1)
+		<-release
+
+		return nil
+	}, nilhealth.WithTimeout(time.Minute))
+	server := ngrpc.NewServer()
+	grpc_testing.RegisterTestServiceServer(server, panicServer{})
+
+	run := ngrpc.Run(server, ngrpc.WithAddress("unix://"+endpoint), ngrpc.WithHealth(registry))
+	done := make(chan error, 1)
+	go func() { done <- run(ctx) }()
+
+	// The server serves while the check is still running.
+	resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	close(release)
+	require.NoError(t, waitServing(ctx, client, ""))
+	require.NoError(t, waitServing(ctx, client, grpc_testing.TestService_ServiceDesc.ServiceName))
+	// The check is evaluated once for all services.
+	assert.Equal(t, int32(1), checked.Load())
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestRun_log(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	control := log.NewControl()
+	done := make(chan error, 1)
+	go func() { done <- ngrpc.Run(nil, ngrpc.WithListener(listener), ngrpc.WithLogService(control))(ctx) }()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	client := pb.NewLogServiceClient(conn)
+	list, err := client.ListLevels(ctx, &pb.ListLevelsRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	assert.Empty(t, list.GetLevels())
+
+	set, err := client.SetLevel(ctx, &pb.SetLevelRequest{Name: "app", Level: "debug", Ttl: durationpb.New(time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, set.GetLevels(), 1)
+	assert.Equal(t, "app", set.GetLevels()[0].GetName())
+	assert.Equal(t, "DEBUG", set.GetLevels()[0].GetLevel())
+	assert.WithinDuration(t, time.Now().Add(time.Minute), set.GetLevels()[0].GetExpiry().AsTime(), time.Second)
+	level, ok := control.Level("app")
+	assert.True(t, ok)
+	assert.Equal(t, slog.LevelDebug, level)
+
+	_, err = client.SetLevel(ctx, &pb.SetLevelRequest{Name: "app", Level: "verbose"})
+	require.EqualError(t, err, `rpc error: code = InvalidArgument desc = slog: level string "verbose": unknown name`)
+
+	reset, err := client.ResetLevel(ctx, &pb.ResetLevelRequest{Name: "app"})
+	require.NoError(t, err)
+	assert.Empty(t, reset.GetLevels())
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestRun_listener(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	done := make(chan error, 1)
+	go func() { done <- ngrpc.Run(nil, ngrpc.WithListener(listener))(ctx) }()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	require.NoError(t, waitServing(ctx, grpc_health_v1.NewHealthClient(conn), ""))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestRun_bound(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var bound socket.Bound
+	done := make(chan error, 1)
+	go func() { done <- ngrpc.Run(nil, ngrpc.WithAddress("localhost:0"), ngrpc.WithBound(&bound))(ctx) }()
+
+	addrs, err := bound.Addrs(ctx)
+	require.NoError(t, err)
+	require.Len(t, addrs, 1)
+	conn, err := grpc.NewClient(addrs[0].String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestRunNew_restart(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	address := "unix:" + filepath.Join(t.TempDir(), "grpc.sock")
+	restarts := make(chan func(), 1)
+	served := 0
+	runner := nilgo.New(
+		nilgo.Named("grpc",
+			ngrpc.RunNew(func() *grpc.Server { return ngrpc.NewServer() }, ngrpc.WithAddress(address)),
+			nilgo.RestartOn(func(restart func()) func() {
+				restarts <- restart
+
+				return func() {}
+			}),
+		),
+		nilgo.Named("client", func(ctx context.Context) error {
+			defer cancel()
+
+			conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				return err
+			}
+			defer func() { _ = conn.Close() }()
+
+			for range 2 {
+				restart := <-restarts
+				if err := waitServing(ctx, grpc_health_v1.NewHealthClient(conn), ""); err != nil {
+					return err
+				}
+				served++
+				restart()
+			}
+
+			return nil
+		}, nilgo.After("grpc")),
+	)
+	require.NoError(t, runner.Run(ctx))
+	// The restarted run serves with a new server.
+	assert.Equal(t, 2, served)
+}
+
+func TestRun_restart(t *testing.T) {
+	t.Parallel()
+
+	restarted := false
+	runner := nilgo.New(
+		nilgo.Named("grpc",
+			ngrpc.Run(nil, ngrpc.WithAddress("localhost:0")),
+			nilgo.RestartOn(func(restart func()) func() {
+				if !restarted {
+					restarted = true
+					restart()
+				}
+
+				return func() {}
+			}),
+		),
+	)
+	err := runner.Run(context.Background())
+	require.ErrorContains(t, err, "gRPC server can not serve again after stop, use grpc.RunNew for the run which restarts")
+}
+
+// waitServing checks the health of the service until it's serving.
+// It polls instead of watching, since the watch stream blocks graceful stop of the server.
+func waitServing(ctx context.Context, client grpc_health_v1.HealthClient, service string) error {
+	for {
+		resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service}, grpc.WaitForReady(true))
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() == grpc_health_v1.HealthCheckResponse_SERVING {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
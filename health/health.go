@@ -0,0 +1,206 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package health provides a registry of liveness and readiness checks,
+// which is shared by HTTP and gRPC servers to report the health of the application.
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nil-go/nilgo/internal/recovery"
+)
+
+// Kind is the kind of health check.
+type Kind int
+
+const (
+	// Liveness checks whether the application is alive, or it should be restarted.
+	Liveness Kind = iota + 1
+	// Readiness checks whether the application is ready to serve traffic.
+	// It includes all liveness checks.
+	Readiness
+)
+
+// Status is the status of health check.
+type Status string
+
+const (
+	// StatusUp means the check passes.
+	StatusUp Status = "up"
+	// StatusDown means the check fails.
+	StatusDown Status = "down"
+)
+
+// Report is the result of health checks.
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// Service reports the status of the checks which apply to the given service,
+// i.e. the checks without services and the checks registered for the service,
+// so the report of all checks answers for each service without checking again.
+func (r Report) Service(service string) Status {
+	for _, result := range r.Checks {
+		if result.Status == StatusDown && (len(result.Services) == 0 || slices.Contains(result.Services, service)) {
+			return StatusDown
+		}
+	}
+
+	return StatusUp
+}
+
+// Result is the result of a single health check.
+type Result struct {
+	Status   Status   `json:"status"`
+	Error    string   `json:"error,omitempty"`
+	Services []string `json:"services,omitempty"`
+}
+
+// Registry holds named health checks.
+//
+// To create a new Registry, call [New].
+type Registry struct {
+	mutex  sync.RWMutex
+	checks map[string]*check
+}
+
+// New creates a new Registry.
+func New() *Registry {
+	return &Registry{checks: make(map[string]*check)}
+}
+
+// Register registers the check with the given name to the Registry.
+// It replaces the check if there is one registered with the same name.
+//
+// The check should return nil if it passes. By default, it's a readiness check with 1 second timeout.
+func (r *Registry) Register(name string, checkFunc func(context.Context) error, opts ...Option) {
+	option := &options{timeout: time.Second}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.checks[name] = &check{options: *option, name: name, check: checkFunc}
+}
+
+// Unregister removes the check with the given name from the Registry.
+func (r *Registry) Unregister(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.checks, name)
+}
+
+// Check executes the checks with the given kind in parallel and reports the results.
+// If services are provided, it only executes the checks without services
+// and the checks registered for any of the given services.
+func (r *Registry) Check(ctx context.Context, kind Kind, services ...string) Report {
+	r.mutex.RLock()
+	checks := make(map[string]*check, len(r.checks))
+	for name, check := range r.checks {
+		if kind == Liveness && !check.liveness {
+			continue
+		}
+		if len(services) > 0 && len(check.services) > 0 &&
+			!slices.ContainsFunc(check.services, func(service string) bool { return slices.Contains(services, service) }) {
+			continue
+		}
+		checks[name] = check
+	}
+	r.mutex.RUnlock()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(checks))}
+	var (
+		mutex     sync.Mutex
+		waitGroup sync.WaitGroup
+	)
+	waitGroup.Add(len(checks))
+	for name, check := range checks {
+		go func() {
+			defer waitGroup.Done()
+
+			result := Result{Status: StatusUp, Services: check.services}
+			if err := check.run(ctx); err != nil {
+				result.Status, result.Error = StatusDown, err.Error()
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			report.Checks[name] = result
+			if result.Status == StatusDown {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	return report
+}
+
+type check struct {
+	options
+	name  string
+	check func(context.Context) error
+
+	mutex     sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+func (c *check) run(ctx context.Context) error {
+	c.mutex.Lock()
+	if c.cacheTTL > 0 && time.Since(c.checkedAt) < c.cacheTTL {
+		defer c.mutex.Unlock()
+
+		return c.err
+	}
+	c.mutex.Unlock()
+
+	// The check executes outside the lock, so the concurrent probes do not wait for the slow check.
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- recovery.Recover(ctx, c.check, slog.String("check", c.name))
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("check timeout after %s: %w", c.timeout, ctx.Err())
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.checkedAt, c.err = time.Now(), err
+
+	return err
+}
+
+// Default returns the default Registry which is used by gRPC and admin servers by default.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register registers the check with the given name to the default Registry.
+func Register(name string, check func(context.Context) error, opts ...Option) {
+	defaultRegistry.Register(name, check, opts...)
+}
+
+// Unregister removes the check with the given name from the default Registry.
+func Unregister(name string) {
+	defaultRegistry.Unregister(name)
+}
+
+var defaultRegistry = New() //nolint:gochecknoglobals
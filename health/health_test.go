@@ -0,0 +1,171 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nil-go/nilgo/health"
+	"github.com/nil-go/nilgo/internal/assert"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	t.Parallel()
+
+	registry := health.New()
+	registry.Register("alive", func(context.Context) error { return nil }, health.WithLiveness())
+	registry.Register("db", func(context.Context) error { return errors.New("connection refused") },
+		health.WithServices("user.Service"))
+	registry.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return nil
+	}, health.WithServices("order.Service"), health.WithTimeout(10*time.Millisecond))
+	registry.Register("panic", func(context.Context) error { panic("boom") }, health.WithServices("panic.Service"))
+
+	testcases := []struct {
+		description string
+		kind        health.Kind
+		services    []string
+		expected    health.Report
+	}{
+		{
+			description: "liveness",
+			kind:        health.Liveness,
+			expected: health.Report{
+				Status: health.StatusUp,
+				Checks: map[string]health.Result{"alive": {Status: health.StatusUp}},
+			},
+		},
+		{
+			description: "readiness",
+			kind:        health.Readiness,
+			expected: health.Report{
+				Status: health.StatusDown,
+				Checks: map[string]health.Result{
+					"alive": {Status: health.StatusUp},
+					"db": {
+						Status: health.StatusDown, Error: "connection refused", Services: []string{"user.Service"},
+					},
+					"slow": {
+						Status:   health.StatusDown,
+						Error:    "check timeout after 10ms: context deadline exceeded",
+						Services: []string{"order.Service"},
+					},
+					"panic": {Status: health.StatusDown, Error: "panic: boom", Services: []string{"panic.Service"}},
+				},
+			},
+		},
+		{
+			description: "service",
+			kind:        health.Readiness,
+			services:    []string{"user.Service"},
+			expected: health.Report{
+				Status: health.StatusDown,
+				Checks: map[string]health.Result{
+					"alive": {Status: health.StatusUp},
+					"db": {
+						Status: health.StatusDown, Error: "connection refused", Services: []string{"user.Service"},
+					},
+				},
+			},
+		},
+		{
+			description: "unknown service",
+			kind:        health.Readiness,
+			services:    []string{"unknown.Service"},
+			expected: health.Report{
+				Status: health.StatusUp,
+				Checks: map[string]health.Result{"alive": {Status: health.StatusUp}},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			report := registry.Check(context.Background(), testcase.kind, testcase.services...)
+			assert.Equal(t, testcase.expected, report)
+		})
+	}
+}
+
+func TestReport_Service(t *testing.T) {
+	t.Parallel()
+
+	report := health.Report{
+		Status: health.StatusDown,
+		Checks: map[string]health.Result{
+			"alive": {Status: health.StatusUp},
+			"db":    {Status: health.StatusDown, Error: "connection refused", Services: []string{"user.Service"}},
+			"cache": {Status: health.StatusUp, Services: []string{"order.Service"}},
+		},
+	}
+	assert.Equal(t, health.StatusDown, report.Service("user.Service"))
+	assert.Equal(t, health.StatusUp, report.Service("order.Service"))
+	assert.Equal(t, health.StatusUp, report.Service("unknown.Service"))
+
+	report.Checks["alive"] = health.Result{Status: health.StatusDown, Error: "deadlock"}
+	assert.Equal(t, health.StatusDown, report.Service("order.Service"))
+}
+
+func TestRegistry_Check_cache(t *testing.T) {
+	t.Parallel()
+
+	var count atomic.Int32
+	registry := health.New()
+	registry.Register("cached", func(context.Context) error {
+		count.Add(1)
+
+		return nil
+	}, health.WithCacheTTL(time.Minute))
+
+	registry.Check(context.Background(), health.Readiness)
+	registry.Check(context.Background(), health.Readiness)
+	assert.Equal(t, int32(1), count.Load())
+}
+
+func TestRegistry_Check_concurrent(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	registry := health.New()
+	registry.Register("slow", func(context.Context) error {
+		started <- struct{}{}
+		<-release
+
+		return nil
+	}, health.WithTimeout(time.Minute))
+
+	reports := make(chan health.Report, 2)
+	for range 2 {
+		go func() {
+			reports <- registry.Check(context.Background(), health.Readiness)
+		}()
+	}
+	// Both probes execute the check, instead of waiting for the other one.
+	<-started
+	<-started
+	close(release)
+	for range 2 {
+		assert.Equal(t, health.StatusUp, (<-reports).Status)
+	}
+}
+
+func TestRegistry_Unregister(t *testing.T) {
+	t.Parallel()
+
+	registry := health.New()
+	registry.Register("db", func(context.Context) error { return errors.New("connection refused") })
+	registry.Unregister("db")
+
+	report := registry.Check(context.Background(), health.Readiness)
+	assert.Equal(t, health.Report{Status: health.StatusUp, Checks: map[string]health.Result{}}, report)
+}
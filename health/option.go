@@ -0,0 +1,53 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package health
+
+import "time"
+
+// WithLiveness marks the check as a liveness check.
+// Liveness checks are also used for readiness.
+func WithLiveness() Option {
+	return func(options *options) {
+		options.liveness = true
+	}
+}
+
+// WithServices restricts the check to the given services, e.g. full names of gRPC services.
+// By default, the check applies to all services.
+func WithServices(services ...string) Option {
+	return func(options *options) {
+		options.services = append(options.services, services...)
+	}
+}
+
+// WithTimeout provides the timeout for executing the check.
+// The check fails if it does not return within the timeout.
+//
+// By default, it's 1 second.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *options) {
+		options.timeout = timeout
+	}
+}
+
+// WithCacheTTL caches the result of the check for the given duration,
+// so that frequent probes do not overload the dependencies.
+//
+// By default, the result is not cached.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(options *options) {
+		options.cacheTTL = ttl
+	}
+}
+
+type (
+	// Option configures the health check with specific options.
+	Option  func(*options)
+	options struct {
+		liveness bool
+		services []string
+		timeout  time.Duration
+		cacheTTL time.Duration
+	}
+)
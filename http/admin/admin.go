@@ -0,0 +1,146 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package admin provides the admin HTTP server as one consistent operational endpoint,
+// which serves pprof, config explanation, log levels, health checks, runtime information and Runner state
+// on a separate address from the public servers, e.g. a unix socket or localhost port.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/container"
+	"github.com/nil-go/nilgo/health"
+	nhttp "github.com/nil-go/nilgo/http"
+	"github.com/nil-go/nilgo/http/internal"
+)
+
+// Run wraps start/stop of the admin server in a single run function.
+//
+// It serves following endpoints with the built-in interceptors of [nhttp.Run]:
+//   - `/debug/pprof/` for pprof profiles;
+//   - `/_config/{path}` for config explanation (see [nhttp.WithConfigService]);
+//   - `/_log` for log levels of the default log.Control (see [nhttp.WithLogService]);
+//   - `/healthz` and `/readyz` for liveness and readiness checks;
+//   - `/runtime` for runtime information, e.g. build info, GOMAXPROCS, GOMEMLIMIT, uptime
+//     and the decision of tuning them for the container limits (see [nilgo.WithContainerLimits]);
+//   - `/runner` for the state of the Runner which executes the run.
+func Run(opts ...Option) func(context.Context) error {
+	option := &options{}
+	for _, opt := range opts {
+		opt(option)
+	}
+	if len(option.addresses) == 0 {
+		option.addresses = []string{"localhost:6060"}
+	}
+	if option.health == nil {
+		option.health = health.Default()
+	}
+	if option.timeout == 0 {
+		// It has to be longer than the default duration of CPU profile, which is 30 seconds.
+		option.timeout = time.Minute
+	}
+
+	var runner atomic.Pointer[nilgo.Runner]
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("GET /runtime", runtimeInfo)
+	mux.HandleFunc("GET /runner", runnerState(&runner))
+
+	httpOpts := []nhttp.Option{
+		nhttp.WithAddress(option.addresses...),
+		nhttp.WithTimeout(option.timeout),
+		nhttp.WithConfigService(option.configs...),
+		nhttp.WithLogService(nil),
+		nhttp.WithHealth(option.health),
+	}
+	run := nhttp.Run(&http.Server{Handler: mux}, append(httpOpts, option.httpOpts...)...)
+
+	return func(ctx context.Context) error {
+		if r, ok := nilgo.FromContext(ctx); ok {
+			runner.Store(&r)
+		}
+
+		// The addresses of the admin server are not the addresses in config `server.http.addresses`.
+		return run(internal.WithoutEffective(ctx))
+	}
+}
+
+func runtimeInfo(writer http.ResponseWriter, _ *http.Request) {
+	info := struct {
+		GoVersion    string            `json:"goVersion"`
+		Path         string            `json:"path,omitempty"`
+		Version      string            `json:"version,omitempty"`
+		Settings     map[string]string `json:"settings,omitempty"`
+		GOOS         string            `json:"goos"`
+		GOARCH       string            `json:"goarch"`
+		NumCPU       int               `json:"numCPU"`
+		GOMAXPROCS   int               `json:"gomaxprocs"`
+		GOMEMLIMIT   int64             `json:"gomemlimit"`
+		NumGoroutine int               `json:"numGoroutine"`
+		StartTime    time.Time         `json:"startTime"`
+		Uptime       string            `json:"uptime"`
+		// Container is the decision of tuning the runtime for the container limits if it's tuned.
+		Container *container.Decision `json:"container,omitempty"`
+	}{
+		GoVersion:    runtime.Version(),
+		GOOS:         runtime.GOOS,
+		GOARCH:       runtime.GOARCH,
+		NumCPU:       runtime.NumCPU(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		GOMEMLIMIT:   debug.SetMemoryLimit(-1), // Negative input does not adjust the limit.
+		NumGoroutine: runtime.NumGoroutine(),
+		StartTime:    startTime,
+		Uptime:       time.Since(startTime).Round(time.Second).String(),
+	}
+	if decision, ok := container.Tuned(); ok {
+		info.Container = &decision
+	}
+	if build, ok := debug.ReadBuildInfo(); ok {
+		info.Path = build.Main.Path
+		info.Version = build.Main.Version
+		info.Settings = make(map[string]string, len(build.Settings))
+		for _, setting := range build.Settings {
+			info.Settings[setting.Key] = setting.Value
+		}
+	}
+
+	writeJSON(writer, info)
+}
+
+func runnerState(runner *atomic.Pointer[nilgo.Runner]) func(http.ResponseWriter, *http.Request) {
+	return func(writer http.ResponseWriter, _ *http.Request) {
+		r := runner.Load()
+		if r == nil {
+			http.Error(writer, "admin server is not executed by Runner", http.StatusNotFound)
+
+			return
+		}
+
+		writeJSON(writer, struct {
+			State string `json:"state"`
+		}{State: r.State().String()})
+	}
+}
+
+func writeJSON(writer http.ResponseWriter, value any) {
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+//nolint:gochecknoglobals
+var startTime = time.Now()
@@ -0,0 +1,86 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"runtime"
+	"testing"
+
+	"github.com/nil-go/konf"
+
+	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/health"
+	nhttp "github.com/nil-go/nilgo/http"
+	"github.com/nil-go/nilgo/http/admin"
+	"github.com/nil-go/nilgo/http/internal/assert"
+	"github.com/nil-go/nilgo/socket"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var bound socket.Bound
+	responses := make(map[string]string)
+	runner := nilgo.New(
+		nilgo.Named("admin",
+			admin.Run(
+				admin.WithAddress("localhost:0"),
+				admin.WithConfig(konf.New()),
+				admin.WithHealth(health.New()),
+				admin.WithHTTPOption(nhttp.WithBound(&bound)),
+			),
+			nilgo.Ready(bound.Wait),
+		),
+		nilgo.Named("client", func(ctx context.Context) error {
+			defer cancel()
+
+			addrs, err := bound.Addrs(ctx)
+			if err != nil {
+				return err
+			}
+			paths := []string{"/runtime", "/runner", "/healthz", "/_config/app", "/_config/server", "/_log", "/debug/pprof/"}
+			for _, path := range paths {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addrs[0].String()+path, nil)
+				if err != nil {
+					return err
+				}
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					return err
+				}
+				body, err := io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+				if err != nil {
+					return err
+				}
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				responses[path] = string(body)
+			}
+
+			return nil
+		}, nilgo.After("admin")),
+	)
+	assert.NoError(t, runner.Run(ctx))
+
+	var info struct {
+		GoVersion  string `json:"goVersion"`
+		GOMAXPROCS int    `json:"gomaxprocs"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(responses["/runtime"]), &info))
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.Equal(t, runtime.GOMAXPROCS(0), info.GOMAXPROCS)
+	assert.Equal(t, `{"state":"running"}`+"\n", responses["/runner"])
+	assert.Equal(t, `{"status":"up"}`+"\n", responses["/healthz"])
+	assert.Equal(t, "app has no configuration.\n\n", responses["/_config/app"])
+	// The addresses of the admin server are not explained as config `server.http.addresses`.
+	assert.Equal(t, "server has no configuration.\n\n", responses["/_config/server"])
+	assert.Equal(t, `{"levels":[]}`+"\n", responses["/_log"])
+}
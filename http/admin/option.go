@@ -0,0 +1,71 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package admin
+
+import (
+	"time"
+
+	"github.com/nil-go/konf"
+
+	"github.com/nil-go/nilgo/health"
+	nhttp "github.com/nil-go/nilgo/http"
+)
+
+// WithAddress provides the address listened by the admin server,
+// typically unix socket address like `unix:admin.sock` or localhost port like `localhost:6060`.
+// See [nhttp.WithAddress] for details.
+//
+// By default, it listens on `localhost:6060`.
+func WithAddress(addresses ...string) Option {
+	return func(options *options) {
+		options.addresses = append(options.addresses, addresses...)
+	}
+}
+
+// WithTimeout provides the duration that timeout admin request.
+//
+// By default, it has 1 minute timeout, which is longer than the default duration of CPU profile.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *options) {
+		options.timeout = timeout
+	}
+}
+
+// WithConfig provides the configs explained by the endpoint `_config/{path}`.
+//
+// By default, it uses the global konf.Config.
+func WithConfig(configs ...*konf.Config) Option {
+	return func(options *options) {
+		options.configs = append(options.configs, configs...)
+	}
+}
+
+// WithHealth provides the health registry which the endpoints `/healthz` and `/readyz` report on.
+//
+// By default, it uses health.Default().
+func WithHealth(registry *health.Registry) Option {
+	return func(options *options) {
+		options.health = registry
+	}
+}
+
+// WithHTTPOption provides the [nhttp.Option](s) for the underlying HTTP server,
+// e.g. [nhttp.WithConfigAuthorizer] to gate the config explanation.
+func WithHTTPOption(opts ...nhttp.Option) Option {
+	return func(options *options) {
+		options.httpOpts = append(options.httpOpts, opts...)
+	}
+}
+
+type (
+	// Option configures the admin server.
+	Option  func(*options)
+	options struct {
+		addresses []string
+		timeout   time.Duration
+		configs   []*konf.Config
+		health    *health.Registry
+		httpOpts  []nhttp.Option
+	}
+)
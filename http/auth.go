@@ -0,0 +1,106 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package http
+
+import (
+	"crypto/subtle"
+	"crypto/x509"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/nil-go/nilgo/internal/listener"
+)
+
+// Authorizer authorizes the request to access the config service.
+// It returns error if the access is denied.
+type Authorizer func(*http.Request) error
+
+// BearerToken returns an Authorizer which allows the request with any of the given bearer tokens
+// in the `Authorization` header.
+func BearerToken(tokens ...string) Authorizer {
+	return func(request *http.Request) error {
+		token, found := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+		if !found || token == "" {
+			return errMissingToken
+		}
+		for _, t := range tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+				return nil
+			}
+		}
+
+		return errInvalidToken
+	}
+}
+
+// ClientCertificate returns an Authorizer which allows the request with the verified TLS client certificate
+// whose common name or subject alternative names match any of the given names.
+// It requires the server serves TLS and verifies client certificates,
+// e.g. http.Server.TLSConfig with tls.RequireAndVerifyClientCert (see [Run]).
+func ClientCertificate(names ...string) Authorizer {
+	return func(request *http.Request) error {
+		if request.TLS == nil || len(request.TLS.VerifiedChains) == 0 || len(request.TLS.VerifiedChains[0]) == 0 {
+			return errMissingCertificate
+		}
+		if slices.ContainsFunc(certificateNames(request.TLS.VerifiedChains[0][0]), func(name string) bool {
+			return slices.Contains(names, name)
+		}) {
+			return nil
+		}
+
+		return errUnknownClient
+	}
+}
+
+func certificateNames(certificate *x509.Certificate) []string {
+	names := []string{certificate.Subject.CommonName}
+	names = append(names, certificate.DNSNames...)
+	names = append(names, certificate.EmailAddresses...)
+	for _, uri := range certificate.URIs {
+		names = append(names, uri.String())
+	}
+
+	return names
+}
+
+// guard only allows the authorized requests on the config listeners to access the handler,
+// and logs the denied attempts for audit.
+func guard(authorizer Authorizer, restricted *atomic.Bool, handler http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		var err error
+		if restricted.Load() {
+			if local, _ := request.Context().Value(http.LocalAddrContextKey).(net.Addr); !listener.Tagged(local) {
+				err = errListener
+			}
+		}
+		if err == nil && authorizer != nil {
+			err = authorizer(request)
+		}
+		if err != nil {
+			slog.LogAttrs(request.Context(), slog.LevelWarn, "Access to config service is denied.",
+				slog.String("path", request.URL.Path),
+				slog.String("remote", request.RemoteAddr),
+				slog.Any("error", err),
+			)
+			http.Error(writer, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+
+			return
+		}
+
+		handler(writer, request)
+	}
+}
+
+var (
+	errMissingToken       = errors.New("missing bearer token")
+	errInvalidToken       = errors.New("invalid bearer token")
+	errMissingCertificate = errors.New("missing verified client certificate")
+	errUnknownClient      = errors.New("unknown client certificate")
+	errListener           = errors.New("config service is not served on the listener")
+)
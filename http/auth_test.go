@@ -0,0 +1,178 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package http_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	nhttp "github.com/nil-go/nilgo/http"
+	"github.com/nil-go/nilgo/http/internal/assert"
+	"github.com/nil-go/nilgo/socket"
+)
+
+func TestAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	withToken := func(token string) *http.Request {
+		request := httptest.NewRequest(http.MethodGet, "/_config/app", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+
+		return request
+	}
+	withCertificate := func(name string) *http.Request {
+		request := httptest.NewRequest(http.MethodGet, "/_config/app", nil)
+		request.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: name}}}},
+		}
+
+		return request
+	}
+
+	testcases := []struct {
+		description string
+		authorizer  nhttp.Authorizer
+		request     *http.Request
+		err         string
+	}{
+		{
+			description: "valid token",
+			authorizer:  nhttp.BearerToken("old", "new"),
+			request:     withToken("new"),
+		},
+		{
+			description: "invalid token",
+			authorizer:  nhttp.BearerToken("old", "new"),
+			request:     withToken("other"),
+			err:         "invalid bearer token",
+		},
+		{
+			description: "missing token",
+			authorizer:  nhttp.BearerToken("token"),
+			request:     httptest.NewRequest(http.MethodGet, "/_config/app", nil),
+			err:         "missing bearer token",
+		},
+		{
+			description: "known client",
+			authorizer:  nhttp.ClientCertificate("admin"),
+			request:     withCertificate("admin"),
+		},
+		{
+			description: "unknown client",
+			authorizer:  nhttp.ClientCertificate("admin"),
+			request:     withCertificate("other"),
+			err:         "unknown client certificate",
+		},
+		{
+			description: "missing certificate",
+			authorizer:  nhttp.ClientCertificate("admin"),
+			request:     httptest.NewRequest(http.MethodGet, "/_config/app", nil),
+			err:         "missing verified client certificate",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			err := testcase.authorizer(testcase.request)
+			if testcase.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testcase.err)
+			}
+		})
+	}
+}
+
+func TestClientCertificate_mTLS(t *testing.T) {
+	t.Parallel()
+
+	ca, caKey := certificate(t, "ca", nil, nil)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	serverCert, serverKey := certificate(t, "localhost", ca, caKey)
+	server := &http.Server{
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{serverCert.Raw}, PrivateKey: serverKey}},
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var bound socket.Bound
+	done := make(chan error, 1)
+	go func() {
+		done <- nhttp.Run(server,
+			nhttp.WithAddress("localhost:0"),
+			nhttp.WithBound(&bound),
+			nhttp.WithConfigService(),
+			nhttp.WithConfigAuthorizer(nhttp.ClientCertificate("admin")),
+		)(ctx)
+	}()
+	addrs, err := bound.Addrs(ctx)
+	assert.NoError(t, err)
+
+	for name, status := range map[string]int{"admin": http.StatusOK, "other": http.StatusForbidden} {
+		clientCert, clientKey := certificate(t, name, ca, caKey)
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      pool,
+			Certificates: []tls.Certificate{{Certificate: [][]byte{clientCert.Raw}, PrivateKey: clientKey}},
+			MinVersion:   tls.VersionTLS12,
+		}}}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+addrs[0].String()+"/_config/app", nil)
+		assert.NoError(t, err)
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, status, resp.StatusCode)
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+// certificate creates the certificate with the given name, which is self-signed if the parent is nil.
+func certificate(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (
+	*x509.Certificate, *ecdsa.PrivateKey,
+) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return cert, key
+}
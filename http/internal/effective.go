@@ -0,0 +1,21 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package internal
+
+import "context"
+
+// WithoutEffective returns a copy of ctx in which the server does not record its effective values,
+// e.g. the admin server, whose addresses are not the addresses in config `server.http.addresses`.
+func WithoutEffective(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutEffectiveKey{}, true)
+}
+
+// EffectiveSkipped returns true if the server does not record its effective values (see [WithoutEffective]).
+func EffectiveSkipped(ctx context.Context) bool {
+	skipped, _ := ctx.Value(withoutEffectiveKey{}).(bool)
+
+	return skipped
+}
+
+type withoutEffectiveKey struct{}
@@ -0,0 +1,52 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/nil-go/nilgo/log"
+)
+
+// levels serves the log levels in the control:
+// GET lists the levels, PUT sets the level with query `name`, `level` and optional `ttl`,
+// and DELETE resets the level with query `name`. All of them respond the levels after the change in JSON.
+func levels(control *log.Control) func(http.ResponseWriter, *http.Request) {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		query := request.URL.Query()
+		switch request.Method {
+		case http.MethodPut:
+			var level slog.Level
+			if err := level.UnmarshalText([]byte(query.Get("level"))); err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+			var ttl time.Duration
+			if t := query.Get("ttl"); t != "" {
+				var err error
+				if ttl, err = time.ParseDuration(t); err != nil {
+					http.Error(writer, fmt.Sprintf("invalid ttl: %v", err), http.StatusBadRequest)
+
+					return
+				}
+			}
+			control.Set(query.Get("name"), level, ttl)
+		case http.MethodDelete:
+			control.Reset(query.Get("name"))
+		default:
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(writer).Encode(struct {
+			Levels []log.Level `json:"levels"`
+		}{Levels: control.Levels()}); err != nil {
+			slog.LogAttrs(request.Context(), slog.LevelWarn, "Could not write log levels.", slog.Any("error", err))
+		}
+	}
+}
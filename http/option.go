@@ -4,34 +4,49 @@
 package http
 
 import (
-	"slices"
-	"strings"
+	"net"
 	"time"
 
 	"github.com/nil-go/konf"
+
+	"github.com/nil-go/nilgo/health"
+	"github.com/nil-go/nilgo/log"
+	"github.com/nil-go/nilgo/socket"
 )
 
 // WithAddress provides the address listened by the HTTP server.
-// It should be either tcp address like `:8080` or unix socket address like `unix:nilgo.sock`.
+// It should be either tcp address like `:8080`, unix socket address like `unix:nilgo.sock`,
+// inherited file descriptor like `fd:3`, or systemd socket activation like `systemd:http`.
+// See [socket.Listen] for details.
 //
-// By default, it listens on `localhost:8080`  or `:${PORT}` if the environment variable exists.
+// By default, it listens on the addresses in config `server.http.addresses`,
+// or `localhost:8080`  or `:${PORT}` if the environment variable exists.
 func WithAddress(addresses ...string) Option {
 	return func(options *options) {
-		options.addresses = slices.Grow(options.addresses, len(addresses))
-		for _, address := range addresses {
-			network := "tcp"
-			if strings.HasPrefix(address, unix+":") {
-				network = unix
-				address = strings.TrimPrefix(address[5:], "//")
-			}
-			options.addresses = append(options.addresses, socket{network: network, address: address})
-		}
+		options.addresses = append(options.addresses, addresses...)
+	}
+}
+
+// WithListener provides the listeners for the HTTP server, which are listened by the caller,
+// e.g. pre-bound listeners in tests.
+func WithListener(listeners ...net.Listener) Option {
+	return func(options *options) {
+		options.listeners = append(options.listeners, listeners...)
+	}
+}
+
+// WithBound provides the handle to report the addresses of the listeners once all of them are bound,
+// e.g. the real port of `localhost:0`.
+func WithBound(bound *socket.Bound) Option {
+	return func(options *options) {
+		options.bound = bound
 	}
 }
 
 // WithTimeout provides the duration that timeout http request.
 //
-// By default, it has 10 seconds timeout.
+// By default, it uses the duration in config `server.http.timeout`, or 10 seconds timeout.
+// The change of the config applies to the handler in place if the config is watched.
 func WithTimeout(timeout time.Duration) Option {
 	return func(options *options) {
 		options.timeout = timeout
@@ -39,8 +54,14 @@ func WithTimeout(timeout time.Duration) Option {
 }
 
 // WithConfigService registers the endpoint `_config/{path}` for config explanation.
+// It responds the resolved values of keys in JSON if the request accepts `application/json`
+// (see config.Values), or streams the values as Server-Sent Events at first and then each time they change
+// if the request accepts `text/event-stream`, otherwise the text explanation.
+// The values in JSON and Server-Sent Events are only available for the configs created by config.New,
+// and it responds 412 Precondition Failed for other configs, while the text explanation is always available.
 //
 // It uses the global konf.Config if the configs are not provided.
+// The explanation also includes the effective values of the server options, e.g. `server.http.timeout`.
 func WithConfigService(configs ...*konf.Config) Option {
 	return func(options *options) {
 		if options.configs == nil {
@@ -50,16 +71,66 @@ func WithConfigService(configs ...*konf.Config) Option {
 	}
 }
 
+// WithLogService registers the endpoint `_log` to change the log levels in the log.Control at runtime.
+// `GET /_log` lists the levels, `PUT /_log?name={name}&level={level}&ttl={ttl}` sets the level
+// for the logger group or source package with the name, which reverts after the optional ttl,
+// and `DELETE /_log?name={name}` resets it. They all respond the levels after the change in JSON.
+//
+// It uses log.Default() if the control is nil. The access is gated the same as the config service.
+func WithLogService(control *log.Control) Option {
+	return func(options *options) {
+		if control == nil {
+			control = log.Default()
+		}
+		options.logControl = control
+	}
+}
+
+// WithHealth serves liveness and readiness checks of the health registry at `/healthz` and `/readyz`,
+// e.g. health.Default().
+//
+// By default, they are not served, so the paths are left to the handler of the server.
+func WithHealth(registry *health.Registry) Option {
+	return func(options *options) {
+		options.health = registry
+	}
+}
+
+// WithConfigAuthorizer provides the Authorizer to gate the access to the config service and log service,
+// e.g. BearerToken, ClientCertificate or a custom function. The denied attempts are logged for audit.
+//
+// By default, the config service and log service are accessible to any caller.
+func WithConfigAuthorizer(authorizer Authorizer) Option {
+	return func(options *options) {
+		options.configAuthorizer = authorizer
+	}
+}
+
+// WithConfigAddress provides the addresses which the HTTP server also listens on,
+// e.g. unix socket address or admin port, and restricts the config service and log service
+// to the listeners of them.
+//
+// By default, the config service and log service are served on all listeners.
+func WithConfigAddress(addresses ...string) Option {
+	return func(options *options) {
+		options.configAddresses = append(options.configAddresses, addresses...)
+	}
+}
+
 type (
 	// Option configures the http server.
 	Option  func(*options)
 	options struct {
-		addresses []socket
+		addresses []string
+		listeners []net.Listener
+		bound     *socket.Bound
 		timeout   time.Duration
 		configs   []*konf.Config
-	}
-	socket struct {
-		network string
-		address string
+		health    *health.Registry
+
+		logControl *log.Control
+
+		configAuthorizer Authorizer
+		configAddresses  []string
 	}
 )
@@ -6,6 +6,8 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -13,110 +15,249 @@ import (
 	"net/http"
 	"os"
 	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/nil-go/konf"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 
+	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/config"
+	"github.com/nil-go/nilgo/health"
 	"github.com/nil-go/nilgo/http/internal"
+	"github.com/nil-go/nilgo/internal/effective"
+	"github.com/nil-go/nilgo/internal/listener"
+	"github.com/nil-go/nilgo/socket"
 )
 
-const unix = "unix"
-
 // Run wraps start/stop of the HTTP/1 and HTTP/2 clear text server in a single run function
 // with listening on multiple tcp and unix socket address.
 //
-// It also resister built-in interceptors, e.g recovery, log buffering, and timeout.
+// It also resister built-in interceptors, e.g recovery, log buffering, and timeout,
+// and serves liveness and readiness checks at `/healthz` and `/readyz` in JSON if [WithHealth] is provided.
+// It serves TLS on all listeners if server.TLSConfig is provided, e.g. to verify client certificates
+// for [ClientCertificate].
+//
+// It serves with a copy of the given server for each execution of the run, so the run can restart,
+// e.g. by config.RestartOnChange or restart policies of named runs.
 func Run(server *http.Server, opts ...Option) func(context.Context) error { //nolint:cyclop,funlen,gocognit
 	option := &options{}
 	for _, opt := range opts {
 		opt(option)
 	}
-	if option.timeout == 0 {
-		option.timeout = 10 * time.Second //nolint:mnd
-	}
-	if server == nil {
-		server = &http.Server{
-			ReadTimeout: option.timeout,
-		}
-	}
-	if server.ReadTimeout == 0 {
-		// It has to be longer than the timeout of the handler.
-		server.ReadTimeout = option.timeout * 2 //nolint:mnd
-	}
-	if server.WriteTimeout == 0 {
-		server.WriteTimeout = server.ReadTimeout
-	}
-	if server.IdleTimeout == 0 {
-		server.IdleTimeout = server.ReadTimeout * 3 //nolint:mnd
+	defaultServer := server == nil
+	if defaultServer {
+		server = &http.Server{}
 	}
 
-	if len(option.addresses) == 0 {
-		address := "localhost:8080"
-		if a := os.Getenv("PORT"); a != "" {
-			address = ":" + a
-		}
-		option.addresses = []socket{{network: "tcp", address: address}}
-	}
-
-	handler := server.Handler
-	if handler == nil {
-		handler = http.DefaultServeMux
+	root := server.Handler
+	if root == nil {
+		root = http.DefaultServeMux
 	}
-	if option.configs != nil {
+	var (
+		draining  atomic.Bool
+		effective atomic.Pointer[konf.Config]
+		running   atomic.Pointer[context.Context]
+		// restricted is true if the config service is only served on the config listeners.
+		restricted atomic.Bool
+	)
+	if option.health != nil || option.configs != nil || option.logControl != nil {
 		mux := http.NewServeMux()
-		mux.Handle("/", handler)
-		mux.HandleFunc("GET /_config/{path}", config(option))
-		handler = mux
-	}
-	logHandler := slog.Default().Handler()
-	handler = internal.RecoveryInterceptor(handler, logHandler)
-	if internal.IsSamplingHandler(logHandler) {
-		handler = internal.BufferInterceptor(handler)
+		if option.health != nil {
+			mux.HandleFunc("GET /healthz", check(option.health, health.Liveness, nil))
+			mux.HandleFunc("GET /readyz", check(option.health, health.Readiness, &draining))
+		}
+		if option.configs != nil {
+			mux.HandleFunc("GET /_config/{path}",
+				guard(option.configAuthorizer, &restricted, explain(option, &effective, &running)),
+			)
+		}
+		if option.logControl != nil {
+			handle := guard(option.configAuthorizer, &restricted, levels(option.logControl))
+			mux.HandleFunc("GET /_log", handle)
+			mux.HandleFunc("PUT /_log", handle)
+			mux.HandleFunc("DELETE /_log", handle)
+		}
+		// Dispatch the built-in endpoints explicitly, so other requests reach the handler as they are,
+		// e.g. without the path cleaning and redirects of http.ServeMux.
+		handler := root
+		root = http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if builtin(option, request) {
+				mux.ServeHTTP(writer, request)
+
+				return
+			}
+			handler.ServeHTTP(writer, request)
+		})
 	}
-	handler = http.TimeoutHandler(handler, option.timeout, "request timeout")
-	server.Handler = h2c.NewHandler(handler, &http2.Server{})
 
 	return func(ctx context.Context) error {
 		ctx, cancel := context.WithCancelCause(ctx)
 		defer cancel(nil)
 
+		// Resolve options from config, while explicit options take precedence.
+		var cfg serverConfig
+		if err := konf.Unmarshal(configPath, &cfg); err != nil {
+			return fmt.Errorf("load config of HTTP Server: %w", err)
+		}
+		timeout := option.timeout
+		if timeout == 0 {
+			timeout = cfg.Timeout
+		}
+		if timeout == 0 {
+			timeout = defaultTimeout
+		}
+		addresses := option.addresses
+		if len(addresses) == 0 && len(option.listeners) == 0 {
+			addresses = cfg.Addresses
+		}
+		if len(addresses) == 0 && len(option.listeners) == 0 {
+			address := "localhost:8080"
+			if a := os.Getenv("PORT"); a != "" {
+				address = ":" + a
+			}
+			addresses = []string{address}
+		}
+		recordEffective := !internal.EffectiveSkipped(ctx)
+		if recordEffective {
+			effective.Store(effectiveConfig(addresses, timeout))
+			defer func() { config.Release(effective.Load()) }()
+		}
+		var handlerTimeout atomic.Int64
+		handlerTimeout.Store(int64(timeout))
+		if option.timeout == 0 {
+			// Apply the change of timeout to the handler in place,
+			// while the server timeouts keep the values at startup.
+			defer config.OnChange(configPath+".timeout", func(timeout time.Duration) error {
+				if timeout <= 0 {
+					timeout = defaultTimeout
+				}
+				handlerTimeout.Store(int64(timeout))
+				if recordEffective {
+					config.Release(effective.Swap(effectiveConfig(addresses, timeout)))
+				}
+
+				return nil
+			})()
+		}
+
+		// Create the server for each execution, since http.Server can not serve again after shutdown,
+		// e.g. the run restarts on config change (see config.RestartOnChange).
+		srv := newServer(server)
+		if srv.ReadTimeout == 0 {
+			srv.ReadTimeout = timeout
+			if !defaultServer {
+				// It has to be longer than the timeout of the handler.
+				srv.ReadTimeout = timeout * 2 //nolint:mnd
+			}
+		}
+		if srv.WriteTimeout == 0 {
+			srv.WriteTimeout = srv.ReadTimeout
+		}
+		if srv.IdleTimeout == 0 {
+			srv.IdleTimeout = srv.ReadTimeout * 3 //nolint:mnd
+		}
+		handler := root
+		logHandler := slog.Default().Handler()
+		handler = internal.RecoveryInterceptor(handler, logHandler)
+		if internal.IsSamplingHandler(logHandler) {
+			handler = internal.BufferInterceptor(handler)
+		}
+		srv.Handler = h2c.NewHandler(
+			http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				if option.configs != nil && strings.HasPrefix(request.URL.Path, "/_config/") && isEventStream(request) {
+					// The config watch stream is not limited by the timeout.
+					handler.ServeHTTP(writer, request)
+
+					return
+				}
+
+				timeout := time.Duration(handlerTimeout.Load())
+				http.TimeoutHandler(handler, timeout, "request timeout").ServeHTTP(writer, request)
+			}),
+			&http2.Server{},
+		)
+
+		draining.Store(false)
+		running.Store(&ctx)
+		if runner, ok := nilgo.FromContext(ctx); ok {
+			// Mark not ready as soon as the runner starts stopping,
+			// so load balancer stops routing traffic while waiting for stop gates.
+			defer runner.Subscribe(func(event nilgo.Event) {
+				if event.Kind == nilgo.EventStateChanged && event.State == nilgo.StateStopping {
+					draining.Store(true)
+				}
+			})()
+		}
 		defer context.AfterFunc(ctx, func() {
+			draining.Store(true)
 			slog.LogAttrs(ctx, slog.LevelInfo, "Starting shutdown HTTP Server...")
-			if err := server.Shutdown(context.WithoutCancel(ctx)); err != nil {
+			if err := srv.Shutdown(context.WithoutCancel(ctx)); err != nil {
 				cancel(fmt.Errorf("shutdown HTTP Server: %w", err))
 			}
 			slog.LogAttrs(ctx, slog.LevelInfo, "Shutdown HTTP Server completed.")
 		})()
 
 		slog.LogAttrs(ctx, slog.LevelInfo, "Starting HTTP Server...")
-		var waitGroup sync.WaitGroup
-		waitGroup.Add(len(option.addresses))
-		if slices.ContainsFunc(option.addresses, func(addr socket) bool { return addr.network == unix }) {
+		if slices.ContainsFunc(addresses, socket.IsUnix) || slices.ContainsFunc(option.configAddresses, socket.IsUnix) {
 			if transport, ok := http.DefaultTransport.(*http.Transport); ok {
 				internal.RegisterUnixProtocol(transport)
 			}
 		}
-		for _, addr := range option.addresses {
-			go func() {
-				defer waitGroup.Done()
+		listeners := slices.Clone(option.listeners)
+		for _, address := range addresses {
+			ls, err := socket.Listen(address)
+			if err != nil {
+				cancel(fmt.Errorf("start listener: %w", err))
 
-				if addr.network == unix {
-					if err := os.RemoveAll(addr.address); err != nil {
-						slog.LogAttrs(ctx, slog.LevelWarn, "Could not delete unix socket file.", slog.Any("error", err))
-					}
-				}
-				listener, err := net.Listen(addr.network, addr.address)
+				break
+			}
+			listeners = append(listeners, ls...)
+		}
+		if len(option.configAddresses) > 0 {
+			restricted.Store(true)
+			for _, address := range option.configAddresses {
+				ls, err := socket.Listen(address)
 				if err != nil {
-					cancel(fmt.Errorf("start listener: %w", err))
+					cancel(fmt.Errorf("start config listener: %w", err))
 
-					return
+					break
 				}
+				for _, l := range ls {
+					// Tag the connections from config listeners, since the local address of connection
+					// may not match the address of listener, e.g. wildcard address.
+					listeners = append(listeners, listener.Tag(l))
+				}
+			}
+		}
+		if srv.TLSConfig != nil {
+			tlsConfig := srv.TLSConfig.Clone()
+			if len(tlsConfig.NextProtos) == 0 {
+				tlsConfig.NextProtos = []string{"h2", "http/1.1"}
+			}
+			for i, l := range listeners {
+				// The TLS connection keeps the local address of the tagged connection from config listeners.
+				listeners[i] = tls.NewListener(l, tlsConfig)
+			}
+		}
+		if option.bound != nil && context.Cause(ctx) == nil {
+			addrs := make([]net.Addr, 0, len(listeners))
+			for _, listener := range listeners {
+				addrs = append(addrs, listener.Addr())
+			}
+			option.bound.Set(addrs)
+		}
+		var waitGroup sync.WaitGroup
+		waitGroup.Add(len(listeners))
+		for _, listener := range listeners {
+			go func() {
+				defer waitGroup.Done()
 
 				slog.LogAttrs(ctx, slog.LevelInfo, fmt.Sprintf("HTTP Server listens on %s.", listener.Addr()))
-				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
 					cancel(fmt.Errorf("start HTTP Server on %s: %w", listener.Addr(), err))
 				}
 			}()
@@ -131,31 +272,199 @@ func Run(server *http.Server, opts ...Option) func(context.Context) error { //no
 	}
 }
 
-func config(option *options) func(write http.ResponseWriter, request *http.Request) {
+// newServer creates a http.Server with the settings of the given server.
+func newServer(server *http.Server) *http.Server {
+	return &http.Server{
+		Addr:                         server.Addr,
+		DisableGeneralOptionsHandler: server.DisableGeneralOptionsHandler,
+		TLSConfig:                    server.TLSConfig,
+		ReadTimeout:                  server.ReadTimeout,
+		ReadHeaderTimeout:            server.ReadHeaderTimeout,
+		WriteTimeout:                 server.WriteTimeout,
+		IdleTimeout:                  server.IdleTimeout,
+		MaxHeaderBytes:               server.MaxHeaderBytes,
+		TLSNextProto:                 server.TLSNextProto,
+		ConnState:                    server.ConnState,
+		ErrorLog:                     server.ErrorLog,
+		BaseContext:                  server.BaseContext,
+		ConnContext:                  server.ConnContext,
+	}
+}
+
+func check(registry *health.Registry, kind health.Kind, draining *atomic.Bool) func(http.ResponseWriter, *http.Request) {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		var report health.Report
+		if draining != nil && draining.Load() {
+			report = health.Report{Status: health.StatusDown}
+		} else {
+			report = registry.Check(request.Context(), kind)
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		if report.Status != health.StatusUp {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(writer).Encode(report); err != nil {
+			slog.LogAttrs(request.Context(), slog.LevelWarn, "Could not write health report.", slog.Any("error", err))
+		}
+	}
+}
+
+// effectiveConfig creates a konf.Config with the effective values of options,
+// so they can be explained along with the configuration, including by config.Values.
+func effectiveConfig(addresses []string, timeout time.Duration) *konf.Config {
+	cfg, _ := config.New(config.WithLoader(effective.Loader{ // It never fails.
+		Name: "http.Run",
+		Values: map[string]any{
+			"server": map[string]any{
+				"http": map[string]any{
+					"addresses": addresses,
+					"timeout":   timeout,
+				},
+			},
+		},
+	}))
+
+	return cfg
+}
+
+func explain(
+	option *options,
+	effective *atomic.Pointer[konf.Config],
+	running *atomic.Pointer[context.Context],
+) func(http.ResponseWriter, *http.Request) {
 	return func(write http.ResponseWriter, request *http.Request) {
+		path := request.PathValue("path")
+		if isEventStream(request) {
+			watch(write, request, path, option.configs, running)
+
+			return
+		}
+
 		var err error
 		defer func() {
 			if err != nil {
-				http.Error(write, err.Error(), http.StatusInternalServerError)
+				http.Error(write, err.Error(), valuesStatus(err))
 			}
 		}()
 
-		path := request.PathValue("path")
-		if len(option.configs) == 0 {
-			_, err = write.Write([]byte(konf.Explain(path)))
-
-			return
+		configs := option.configs
+		if len(configs) == 0 {
+			configs = []*konf.Config{nil} // nil for the default konf.Config.
+		}
+		if cfg := effective.Load(); cfg != nil && cfg.Exists(strings.Split(strings.ToLower(path), ".")) {
+			configs = append(slices.Clip(configs), cfg)
 		}
 
-		for i, config := range option.configs {
-			if i > 0 {
-				if _, err := write.Write([]byte("\n-----\n")); err != nil {
+		if strings.Contains(request.Header.Get("Accept"), "application/json") {
+			values := []config.Value{}
+			for _, cfg := range configs {
+				var vals []config.Value
+				if vals, err = config.Values(cfg, path); err != nil {
 					return
 				}
+				values = append(values, vals...)
 			}
-			if _, err := write.Write([]byte(config.Explain(path))); err != nil {
-				return
+			write.Header().Set("Content-Type", "application/json")
+			err = json.NewEncoder(write).Encode(struct {
+				Values []config.Value `json:"values"`
+			}{Values: values})
+
+			return
+		}
+
+		explanations := make([]string, 0, len(configs))
+		for _, cfg := range configs {
+			if cfg == nil {
+				explanations = append(explanations, konf.Explain(path))
+			} else {
+				explanations = append(explanations, cfg.Explain(path))
 			}
 		}
+		_, err = write.Write([]byte(strings.Join(explanations, "\n-----\n")))
+	}
+}
+
+// watch streams the config values under the path as Server-Sent Events at first and then each time they change,
+// until the client disconnects or the server shuts down.
+func watch(
+	write http.ResponseWriter,
+	request *http.Request,
+	path string,
+	configs []*konf.Config,
+	running *atomic.Pointer[context.Context],
+) {
+	ctx, cancel := context.WithCancel(request.Context())
+	defer cancel()
+	if runCtx := running.Load(); runCtx != nil {
+		// End the stream as soon as the server starts shutdown, so it does not block graceful shutdown.
+		defer context.AfterFunc(*runCtx, cancel)()
+	}
+
+	controller := http.NewResponseController(write)
+	_ = controller.SetWriteDeadline(time.Time{}) // The stream lasts longer than the write timeout.
+	write.Header().Set("Content-Type", "text/event-stream")
+	write.Header().Set("Cache-Control", "no-cache")
+	// Ignore other errors: It only fails while writing to the disconnected client.
+	err := config.WatchValues(ctx, path, func(values []config.Value) error {
+		data, err := json.Marshal(struct {
+			Values []config.Value `json:"values"`
+		}{Values: values})
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		if _, err := fmt.Fprintf(write, "data: %s\n\n", data); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return controller.Flush() //nolint:wrapcheck
+	}, configs...)
+	if errors.Is(err, config.ErrNotTracked) {
+		// Nothing has been written to the client yet.
+		http.Error(write, err.Error(), valuesStatus(err))
 	}
 }
+
+// valuesStatus converts config.ErrNotTracked to the status 412 Precondition Failed
+// like the code FailedPrecondition of the gRPC config service,
+// since the values can only be resolved from the config created by config.New.
+func valuesStatus(err error) int {
+	if errors.Is(err, config.ErrNotTracked) {
+		return http.StatusPreconditionFailed
+	}
+
+	return http.StatusInternalServerError
+}
+
+// builtin reports whether the request is for the enabled built-in endpoints.
+func builtin(option *options, request *http.Request) bool {
+	path, method := request.URL.Path, request.Method
+	get := method == http.MethodGet || method == http.MethodHead
+	switch {
+	case option.health != nil && (path == "/healthz" || path == "/readyz"):
+		return get
+	case option.configs != nil && strings.HasPrefix(path, "/_config/"):
+		// Match the single segment of the pattern `/_config/{path}`.
+		key := strings.TrimPrefix(path, "/_config/")
+
+		return get && key != "" && !strings.Contains(key, "/")
+	case option.logControl != nil && path == "/_log":
+		return get || method == http.MethodPut || method == http.MethodDelete
+	default:
+		return false
+	}
+}
+
+func isEventStream(request *http.Request) bool {
+	return strings.Contains(request.Header.Get("Accept"), "text/event-stream")
+}
+
+type serverConfig struct {
+	Addresses []string
+	Timeout   time.Duration
+}
+
+const (
+	configPath     = "server.http"
+	defaultTimeout = 10 * time.Second
+)
@@ -4,17 +4,29 @@
 package http_test
 
 import (
+	"bufio"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/nil-go/konf"
+
+	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/config"
+	"github.com/nil-go/nilgo/health"
 	nhttp "github.com/nil-go/nilgo/http"
 	"github.com/nil-go/nilgo/http/internal/assert"
+	"github.com/nil-go/nilgo/log"
+	"github.com/nil-go/nilgo/socket"
 )
 
 //nolint:gosec
@@ -107,6 +119,105 @@ func TestRun(t *testing.T) {
 				assert.Equal(t, "_not_found has no configuration.\n\n", string(bytes))
 			},
 		},
+		{
+			description: "without health",
+			server: func() *http.Server {
+				mux := http.NewServeMux()
+				mux.HandleFunc("GET /healthz", func(writer http.ResponseWriter, _ *http.Request) {
+					_, _ = writer.Write([]byte("ok"))
+				})
+
+				return &http.Server{Handler: mux}
+			},
+			assertion: func(endpoint string) {
+				req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, endpoint+"/healthz", nil)
+				assert.NoError(t, err)
+				resp, err := http.DefaultClient.Do(req)
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				bytes, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+				defer func() { _ = resp.Body.Close() }()
+				assert.Equal(t, "ok", string(bytes))
+			},
+		},
+		{
+			description: "with health",
+			server: func() *http.Server {
+				return &http.Server{}
+			},
+			opts: []nhttp.Option{
+				nhttp.WithHealth(func() *health.Registry {
+					registry := health.New()
+					registry.Register("alive", func(context.Context) error { return nil }, health.WithLiveness())
+					registry.Register("db", func(context.Context) error { return errors.New("connection refused") })
+
+					return registry
+				}()),
+			},
+			assertion: func(endpoint string) {
+				for path, expected := range map[string]struct {
+					status int
+					body   string
+				}{
+					"/healthz": {
+						status: http.StatusOK,
+						body:   `{"status":"up","checks":{"alive":{"status":"up"}}}` + "\n",
+					},
+					"/readyz": {
+						status: http.StatusServiceUnavailable,
+						body: `{"status":"down","checks":{"alive":{"status":"up"},` +
+							`"db":{"status":"down","error":"connection refused"}}}` + "\n",
+					},
+				} {
+					req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, endpoint+path, nil)
+					assert.NoError(t, err)
+					resp, err := http.DefaultClient.Do(req)
+					assert.NoError(t, err)
+					assert.Equal(t, expected.status, resp.StatusCode)
+					assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+					bytes, err := io.ReadAll(resp.Body)
+					assert.NoError(t, err)
+					_ = resp.Body.Close()
+					assert.Equal(t, expected.body, string(bytes))
+				}
+			},
+		},
+		{
+			description: "with health and handler",
+			server: func() *http.Server {
+				return &http.Server{
+					Handler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+						_, _ = writer.Write([]byte(request.Method + " " + request.URL.Path))
+					}),
+				}
+			},
+			opts: []nhttp.Option{
+				nhttp.WithHealth(health.New()),
+			},
+			assertion: func(endpoint string) {
+				for _, testcase := range []struct {
+					method string
+					path   string
+					body   string
+				}{
+					{method: http.MethodGet, path: "/healthz", body: `{"status":"up"}` + "\n"},
+					// Other requests reach the handler as they are.
+					{method: http.MethodPost, path: "/healthz", body: "POST /healthz"},
+					{method: http.MethodGet, path: "//users", body: "GET //users"},
+				} {
+					req, err := http.NewRequestWithContext(context.Background(), testcase.method, endpoint+testcase.path, nil)
+					assert.NoError(t, err)
+					resp, err := http.DefaultClient.Do(req)
+					assert.NoError(t, err)
+					assert.Equal(t, http.StatusOK, resp.StatusCode)
+					bytes, err := io.ReadAll(resp.Body)
+					assert.NoError(t, err)
+					_ = resp.Body.Close()
+					assert.Equal(t, testcase.body, string(bytes))
+				}
+			},
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -135,3 +246,480 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+//nolint:paralleltest // It changes the default config.
+func TestRun_config(t *testing.T) {
+	randBytes := make([]byte, 4)
+	_, err := rand.Read(randBytes)
+	assert.NoError(t, err)
+	endpoint := "." + hex.EncodeToString(randBytes) + ".sock"
+	defer func() {
+		_ = os.Remove(endpoint)
+	}()
+
+	values := func(timeout string) map[string]any {
+		return map[string]any{
+			"server": map[string]any{
+				"http": map[string]any{
+					"addresses": "unix:" + endpoint,
+					"timeout":   timeout,
+				},
+			},
+		}
+	}
+	loader := mapLoader{values: values("1s"), onChange: make(chan func(map[string]any), 1)}
+	cfg, err := config.New(config.WithLoader(loader))
+	assert.NoError(t, err)
+	config.SetDefault(cfg)
+	defer config.SetDefault(konf.New())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, cfg.Watch(ctx))
+	}()
+	var bound socket.Bound
+	done := make(chan error, 1)
+	go func() {
+		done <- nhttp.Run(nil, nhttp.WithConfigService(), nhttp.WithHealth(health.New()), nhttp.WithBound(&bound))(ctx)
+	}()
+	_, err = bound.Addrs(ctx)
+	assert.NoError(t, err)
+	// The server subscribes the timeout before it's bound, so it applies the change ahead of this subscription.
+	applied := make(chan time.Duration, 1)
+	defer config.OnChange("server.http.timeout", func(timeout time.Duration) error {
+		applied <- timeout
+
+		return nil
+	})()
+
+	explain := func() string {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "unix:"+endpoint+"/_config/server.http.timeout", nil)
+		assert.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		bytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		return string(bytes)
+	}
+	assert.Equal(t, "server.http.timeout has value[1s] that is loaded by loader[map].\n\n"+
+		"\n-----\n"+
+		"server.http.timeout has value[1s] that is loaded by loader[http.Run].\n\n",
+		explain(),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "unix:"+endpoint+"/_config/server.http", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	bytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `{"values":[`+
+		`{"path":"server.http.addresses","value":"unix:`+endpoint+`","loader":"map"},`+
+		`{"path":"server.http.timeout","value":"1s","loader":"map"},`+
+		`{"path":"server.http.addresses","value":"[unix:`+endpoint+`]","loader":"http.Run"},`+
+		`{"path":"server.http.timeout","value":"1s","loader":"http.Run"}]}`+"\n",
+		string(bytes),
+	)
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, "unix:"+endpoint+"/_config/server.http.timeout", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	events := bufio.NewReader(resp.Body)
+	event, err := events.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, `data: {"values":[{"path":"server.http.timeout","value":"1s","loader":"map"}]}`+"\n", event)
+
+	// Change timeout in place.
+	(<-loader.onChange)(values("2s"))
+	_, _ = events.ReadString('\n') // Skip the blank line between events.
+	event, err = events.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, `data: {"values":[{"path":"server.http.timeout","value":"2s","loader":"map"}]}`+"\n", event)
+	assert.Equal(t, 2*time.Second, <-applied)
+	assert.Equal(t, "server.http.timeout has value[2s] that is loaded by loader[map].\n\n"+
+		"\n-----\n"+
+		"server.http.timeout has value[2s] that is loaded by loader[http.Run].\n\n",
+		explain(),
+	)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestRun_configAccess(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	randBytes := make([]byte, 4)
+	_, err := rand.Read(randBytes)
+	assert.NoError(t, err)
+	public, admin := "."+hex.EncodeToString(randBytes)+".sock", "."+hex.EncodeToString(randBytes)+".admin.sock"
+	defer func() {
+		_ = os.Remove(public)
+		_ = os.Remove(admin)
+	}()
+	public, admin = "unix:"+public, "unix:"+admin
+	var bound socket.Bound
+	done := make(chan error, 1)
+	go func() {
+		done <- nhttp.Run(nil,
+			nhttp.WithAddress(public),
+			nhttp.WithBound(&bound),
+			nhttp.WithConfigService(konf.New()),
+			nhttp.WithConfigAddress(admin),
+			nhttp.WithConfigAuthorizer(nhttp.BearerToken("token")),
+			nhttp.WithHealth(health.New()),
+		)(ctx)
+	}()
+	_, err = bound.Addrs(ctx)
+	assert.NoError(t, err)
+
+	for _, testcase := range []struct {
+		endpoint string
+		token    string
+		accept   string
+		status   int
+	}{
+		{endpoint: public, token: "token", status: http.StatusForbidden},
+		{endpoint: admin, status: http.StatusForbidden},
+		{endpoint: admin, token: "token", status: http.StatusOK},
+		// The values are not available since the config is not created by config.New.
+		{endpoint: admin, token: "token", accept: "application/json", status: http.StatusPreconditionFailed},
+		{endpoint: admin, token: "token", accept: "text/event-stream", status: http.StatusPreconditionFailed},
+	} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, testcase.endpoint+"/_config/app", nil)
+		assert.NoError(t, err)
+		if testcase.token != "" {
+			req.Header.Set("Authorization", "Bearer "+testcase.token)
+		}
+		if testcase.accept != "" {
+			req.Header.Set("Accept", testcase.accept)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, testcase.status, resp.StatusCode)
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestRun_configAccess_wildcard(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var bound socket.Bound
+	done := make(chan error, 1)
+	go func() {
+		done <- nhttp.Run(nil,
+			nhttp.WithAddress("localhost:0"),
+			nhttp.WithBound(&bound),
+			nhttp.WithConfigService(konf.New()),
+			nhttp.WithConfigAddress(":0"), // Wildcard address.
+			nhttp.WithHealth(health.New()),
+		)(ctx)
+	}()
+	addrs, err := bound.Addrs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(addrs))
+	admin, ok := addrs[1].(*net.TCPAddr)
+	assert.Equal(t, true, ok)
+
+	for _, testcase := range []struct {
+		endpoint string
+		status   int
+	}{
+		{endpoint: "http://" + addrs[0].String(), status: http.StatusForbidden},
+		{endpoint: "http://localhost:" + strconv.Itoa(admin.Port), status: http.StatusOK},
+	} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, testcase.endpoint+"/_config/app", nil)
+		assert.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, testcase.status, resp.StatusCode)
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+type mapLoader struct {
+	values   map[string]any
+	onChange chan func(map[string]any)
+}
+
+func (m mapLoader) Load() (map[string]any, error) {
+	return m.values, nil
+}
+
+func (m mapLoader) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	m.onChange <- onChange
+	<-ctx.Done()
+
+	return nil
+}
+
+func (m mapLoader) String() string {
+	return "map"
+}
+
+//nolint:gosec
+func TestRun_runner(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	randBytes := make([]byte, 4) //nolint:makezero
+	_, err := rand.Read(randBytes)
+	assert.NoError(t, err)
+	endpoint := "." + hex.EncodeToString(randBytes) + ".sock"
+	defer func() {
+		_ = os.Remove(endpoint)
+	}()
+	ready := func(ctx context.Context) int {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "unix:"+endpoint+"/readyz", nil)
+		assert.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		return resp.StatusCode
+	}
+
+	var status int
+	runner := nilgo.New(nilgo.WithStopGate(func(ctx context.Context) error {
+		status = ready(ctx)
+
+		return nil
+	}))
+	err = runner.Run(ctx,
+		nhttp.Run(&http.Server{}, nhttp.WithAddress("unix:"+endpoint), nhttp.WithHealth(health.New())),
+		func(ctx context.Context) error {
+			time.Sleep(100 * time.Millisecond) // Wait for server to start.
+			assert.Equal(t, http.StatusOK, ready(ctx))
+			cancel()
+
+			return nil
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestRun_log(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	control := log.NewControl()
+	done := make(chan error, 1)
+	go func() {
+		done <- nhttp.Run(nil, nhttp.WithListener(listener), nhttp.WithLogService(control))(ctx)
+	}()
+
+	testcases := []struct {
+		method   string
+		query    string
+		status   int
+		expected string
+	}{
+		{method: http.MethodGet, status: http.StatusOK, expected: `{"levels":[]}` + "\n"},
+		{
+			method:   http.MethodPut,
+			query:    "?name=app&level=debug",
+			status:   http.StatusOK,
+			expected: `{"levels":[{"name":"app","level":"DEBUG"}]}` + "\n",
+		},
+		{
+			method:   http.MethodPut,
+			query:    "?level=warn",
+			status:   http.StatusOK,
+			expected: `{"levels":[{"name":"","level":"WARN"},{"name":"app","level":"DEBUG"}]}` + "\n",
+		},
+		{
+			method:   http.MethodPut,
+			query:    "?name=app&level=verbose",
+			status:   http.StatusBadRequest,
+			expected: `slog: level string "verbose": unknown name` + "\n",
+		},
+		{
+			method:   http.MethodPut,
+			query:    "?name=app&level=info&ttl=forever",
+			status:   http.StatusBadRequest,
+			expected: `invalid ttl: time: invalid duration "forever"` + "\n",
+		},
+		{
+			method:   http.MethodDelete,
+			query:    "?name=app",
+			status:   http.StatusOK,
+			expected: `{"levels":[{"name":"","level":"WARN"}]}` + "\n",
+		},
+	}
+	for _, testcase := range testcases {
+		req, err := http.NewRequestWithContext(ctx, testcase.method,
+			"http://"+listener.Addr().String()+"/_log"+testcase.query, nil,
+		)
+		assert.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, testcase.status, resp.StatusCode)
+		assert.Equal(t, testcase.expected, string(body))
+	}
+	level, ok := control.Level("app.request")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, slog.LevelWarn, level)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestRun_listener(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	done := make(chan error, 1)
+	go func() {
+		done <- nhttp.Run(nil, nhttp.WithListener(listener), nhttp.WithHealth(health.New()))(ctx)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+listener.Addr().String()+"/healthz", nil)
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestRun_bound(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		bound  socket.Bound
+		status int
+	)
+	runner := nilgo.New(
+		nilgo.Named("http",
+			nhttp.Run(nil, nhttp.WithAddress("localhost:0"), nhttp.WithBound(&bound), nhttp.WithHealth(health.New())),
+			nilgo.Ready(bound.Wait),
+		),
+		nilgo.Named("client", func(ctx context.Context) error {
+			defer cancel()
+
+			addrs, err := bound.Addrs(ctx)
+			if err != nil {
+				return err
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addrs[0].String()+"/healthz", nil)
+			if err != nil {
+				return err
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return err
+			}
+			_ = resp.Body.Close()
+			status = resp.StatusCode
+
+			return nil
+		}, nilgo.After("http")),
+	)
+	assert.NoError(t, runner.Run(ctx))
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestRun_restart(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	randBytes := make([]byte, 4) //nolint:makezero
+	_, err := rand.Read(randBytes)
+	assert.NoError(t, err)
+	socketFile := "." + hex.EncodeToString(randBytes) + ".sock"
+	defer func() {
+		_ = os.Remove(socketFile)
+	}()
+	endpoint := "unix:" + socketFile
+	restarts := make(chan func(), 1)
+	var statuses []int
+	runner := nilgo.New(
+		nilgo.Named("http",
+			nhttp.Run(&http.Server{
+				Handler: http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}),
+			}, nhttp.WithAddress(endpoint)),
+			nilgo.RestartOn(func(restart func()) func() {
+				restarts <- restart
+
+				return func() {}
+			}),
+		),
+		nilgo.Named("client", func(ctx context.Context) error {
+			defer cancel()
+
+			for range 2 {
+				restart := <-restarts
+				statuses = append(statuses, eventualStatus(ctx, endpoint))
+				restart()
+			}
+
+			return nil
+		}, nilgo.After("http")),
+	)
+	assert.NoError(t, runner.Run(ctx))
+	// The restarted run serves with a new server.
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK}, statuses)
+}
+
+// eventualStatus returns the status of the request to the endpoint once the server serves it,
+// or 0 if the server does not serve it within a second.
+func eventualStatus(ctx context.Context, endpoint string) int {
+	for range 100 {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return 0
+		}
+		if resp, err := http.DefaultClient.Do(req); err == nil {
+			_ = resp.Body.Close()
+
+			return resp.StatusCode
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return 0
+}
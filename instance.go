@@ -0,0 +1,227 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nil-go/nilgo/internal/flock"
+)
+
+// ErrLocked is returned by Runner.Run if another instance holds the lock of the pid file
+// provided by [WithSingleInstance].
+var ErrLocked = errors.New("another instance is running")
+
+// instance guards the single instance with an exclusive lock on the pid file.
+// It keeps the lock state for each execution of the Runner, so the Runner can run again,
+// and the concurrent executions lock against each other like separate processes.
+type instance struct {
+	path string
+	wait time.Duration
+
+	mutex sync.Mutex
+	locks map[*execution]*instanceLock
+}
+
+// instanceLock is the lock state of a single execution.
+type instanceLock struct {
+	locked chan struct{}
+	file   *os.File
+	err    error
+}
+
+// state returns the lock state of the execution which ctx belongs to.
+func (i *instance) state(ctx context.Context) *instanceLock {
+	exec, _ := ctx.Value(executionKey{}).(*execution)
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.locks == nil {
+		i.locks = make(map[*execution]*instanceLock)
+	}
+	state, ok := i.locks[exec]
+	if !ok {
+		state = &instanceLock{locked: make(chan struct{})}
+		i.locks[exec] = state
+	}
+
+	return state
+}
+
+// lock acquires the lock on the pid file as a pre run.
+// The failure is reported by the start gate, so the main runs do not start.
+func (i *instance) lock(ctx context.Context) error {
+	state := i.state(ctx)
+	file, err := i.acquire(ctx)
+	i.mutex.Lock()
+	state.file, state.err = file, err
+	i.mutex.Unlock()
+	close(state.locked)
+
+	return nil
+}
+
+// acquire acquires the lock on the pid file, and writes the pid of the process into it.
+func (i *instance) acquire(ctx context.Context) (*os.File, error) {
+	// Stop waiting for the lock once the Runner starts stopping, e.g. by a signal or a failure of other runs.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if runner, ok := FromContext(ctx); ok {
+		defer runner.Subscribe(func(event Event) {
+			if event.Kind == EventStateChanged && event.State == StateStopping {
+				cancel()
+			}
+		})()
+	}
+
+	var deadline <-chan time.Time
+	if i.wait > 0 {
+		timer := time.NewTimer(i.wait)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(lockInterval)
+	defer ticker.Stop()
+
+	waiting := false
+	for {
+		file, err := i.tryLock()
+		switch {
+		case err == nil:
+			slog.LogAttrs(ctx, slog.LevelInfo, "Single instance lock has been acquired.", slog.String("file", i.path))
+
+			return file, nil
+		case !errors.Is(err, flock.ErrWouldBlock):
+			return nil, err
+		case deadline == nil:
+			return nil, fmt.Errorf("lock pid file %s: %w (pid %s)", i.path, ErrLocked, i.holder())
+		case !waiting:
+			waiting = true
+			slog.LogAttrs(ctx, slog.LevelInfo, "Waiting for another instance to release the lock.",
+				slog.String("file", i.path),
+				slog.String("pid", i.holder()),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("lock pid file %s: %w", i.path, ctx.Err())
+		case <-deadline:
+			return nil, fmt.Errorf("lock pid file %s in %s: %w (pid %s)", i.path, i.wait, ErrLocked, i.holder())
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryLock opens the pid file and locks it without blocking.
+// It returns flock.ErrWouldBlock if another instance holds the lock.
+func (i *instance) tryLock() (*os.File, error) {
+	file, err := os.OpenFile(i.path, os.O_RDWR|os.O_CREATE, 0o644) //nolint:gosec,mnd
+	if err != nil {
+		return nil, fmt.Errorf("open pid file: %w", err)
+	}
+	if err = flock.Lock(file); err != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("lock pid file: %w", err)
+	}
+
+	// The previous instance removes the pid file before releasing the lock,
+	// so it has to lock again if the locked file is no longer the one at the path.
+	fileInfo, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("stat pid file: %w", err)
+	}
+	if pathInfo, err := os.Stat(i.path); err != nil || !os.SameFile(fileInfo, pathInfo) {
+		_ = file.Close()
+
+		return i.tryLock()
+	}
+
+	if err := file.Truncate(0); err != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("truncate pid file: %w", err)
+	}
+	if _, err := file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0); err != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("write pid file: %w", err)
+	}
+
+	return file, nil
+}
+
+// holder returns the pid of the instance which holds the lock, or `unknown` if it's not available.
+func (i *instance) holder() string {
+	content, err := os.ReadFile(i.path)
+	if pid := strings.TrimSpace(string(content)); err == nil && pid != "" {
+		return pid
+	}
+
+	return "unknown"
+}
+
+// ready blocks the main runs until the lock is acquired,
+// and fails if the lock is not acquired so the main runs do not start.
+func (i *instance) ready(ctx context.Context) error {
+	state := i.state(ctx)
+	select {
+	case <-ctx.Done():
+	case <-state.locked:
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	switch {
+	case state.err != nil:
+		return state.err
+	case state.file == nil:
+		return errNotLocked
+	default:
+		return nil
+	}
+}
+
+// unlock removes the pid file and releases the lock.
+func (i *instance) unlock(ctx context.Context) error {
+	state := i.state(ctx)
+	<-state.locked // Wait for the pending lock since it stops waiting once the Runner starts stopping.
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	exec, _ := ctx.Value(executionKey{}).(*execution)
+	delete(i.locks, exec) // The next execution starts with a new state.
+	if state.file == nil {
+		return nil
+	}
+
+	var errs []error
+	if err := os.Remove(i.path); err != nil {
+		errs = append(errs, fmt.Errorf("remove pid file: %w", err))
+	}
+	// Closing the file also releases the lock.
+	if err := state.file.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("close pid file: %w", err))
+	}
+	slog.LogAttrs(ctx, slog.LevelInfo, "Single instance lock has been released.", slog.String("file", i.path))
+
+	return errors.Join(errs...)
+}
+
+const lockInterval = 100 * time.Millisecond
+
+var errNotLocked = errors.New("single instance lock is not acquired")
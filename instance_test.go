@@ -0,0 +1,146 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+//go:build unix
+
+package nilgo_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/internal/assert"
+)
+
+func TestWithSingleInstance(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		wait        time.Duration
+		hold        time.Duration
+		err         error
+	}{
+		{
+			description: "fail fast",
+			hold:        time.Second,
+			err:         nilgo.ErrLocked,
+		},
+		{
+			description: "wait timeout",
+			wait:        200 * time.Millisecond,
+			hold:        time.Second,
+			err:         nilgo.ErrLocked,
+		},
+		{
+			description: "wait released",
+			wait:        time.Second,
+			hold:        200 * time.Millisecond,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			pidFile := filepath.Join(t.TempDir(), "nilgo.pid")
+			locked := make(chan struct{})
+			done := make(chan error, 1)
+			go func() {
+				done <- nilgo.New(nilgo.WithSingleInstance(pidFile, 0)).Run(context.Background(),
+					func(context.Context) error {
+						close(locked)
+						time.Sleep(testcase.hold)
+
+						return nil
+					},
+				)
+			}()
+			<-locked
+			content, err := os.ReadFile(pidFile)
+			assert.NoError(t, err)
+			assert.Equal(t, strconv.Itoa(os.Getpid())+"\n", string(content))
+
+			ran := false
+			err = nilgo.New(nilgo.WithSingleInstance(pidFile, testcase.wait)).Run(context.Background(),
+				func(context.Context) error {
+					ran = true
+
+					return nil
+				},
+			)
+			assert.Equal(t, testcase.err, errors.Unwrap(errorCause(err)))
+			assert.Equal(t, testcase.err == nil, ran)
+
+			assert.NoError(t, <-done)
+			_, err = os.Stat(pidFile)
+			assert.Equal(t, true, errors.Is(err, os.ErrNotExist))
+		})
+	}
+}
+
+func TestWithSingleInstance_runError(t *testing.T) {
+	t.Parallel()
+
+	pidFile := filepath.Join(t.TempDir(), "nilgo.pid")
+	err := nilgo.New(nilgo.WithSingleInstance(pidFile, 0)).Run(context.Background(),
+		func(context.Context) error {
+			return errors.New("run error")
+		},
+	)
+	assert.EqualError(t, err, "main run nilgo_test.TestWithSingleInstance_runError.func1: run error")
+	_, err = os.Stat(pidFile)
+	assert.Equal(t, true, errors.Is(err, os.ErrNotExist))
+}
+
+func TestWithSingleInstance_rerun(t *testing.T) {
+	t.Parallel()
+
+	pidFile := filepath.Join(t.TempDir(), "nilgo.pid")
+	runner := nilgo.New(nilgo.WithSingleInstance(pidFile, 0))
+	ran := 0
+	run := func(context.Context) error {
+		ran++
+
+		return nil
+	}
+	// The lock is acquired again for each execution.
+	assert.NoError(t, runner.Run(context.Background(), run))
+	assert.NoError(t, runner.Run(context.Background(), run))
+	assert.Equal(t, 2, ran)
+
+	// The concurrent execution of the same Runner is locked out like another instance.
+	locked, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- runner.Run(context.Background(), func(context.Context) error {
+			close(locked)
+			<-release
+
+			return nil
+		})
+	}()
+	<-locked
+	err := runner.Run(context.Background(), run)
+	assert.Equal(t, nilgo.ErrLocked, errors.Unwrap(errorCause(err)))
+	assert.Equal(t, 2, ran)
+	close(release)
+	assert.NoError(t, <-done)
+	_, err = os.Stat(pidFile)
+	assert.Equal(t, true, errors.Is(err, os.ErrNotExist))
+}
+
+func errorCause(err error) error {
+	var runErr *nilgo.Error
+	if errors.As(err, &runErr) && runErr.Cause != nil {
+		return runErr.Cause.Err
+	}
+
+	return err
+}
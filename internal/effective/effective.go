@@ -0,0 +1,21 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package effective provides the loader of the effective values of server options,
+// so they can be explained along with the configuration.
+package effective
+
+// Loader loads the effective values of options, which is named by the run which applies them, e.g. `http.Run`.
+// It implements konf.Loader.
+type Loader struct {
+	Name   string
+	Values map[string]any
+}
+
+func (l Loader) Load() (map[string]any, error) {
+	return l.Values, nil
+}
+
+func (l Loader) String() string {
+	return l.Name
+}
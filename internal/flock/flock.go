@@ -0,0 +1,10 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package flock provides the exclusive advisory lock on files shared by processes.
+package flock
+
+import "errors"
+
+// ErrWouldBlock is returned by [Lock] if the lock is held by another process.
+var ErrWouldBlock = errors.New("lock is held by another process")
@@ -0,0 +1,16 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+//go:build !unix
+
+package flock
+
+import (
+	"errors"
+	"os"
+)
+
+// Lock places the exclusive lock on the file without blocking, which is unsupported on this platform.
+func Lock(*os.File) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+//go:build unix
+
+package flock
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// Lock places the exclusive lock on the file without blocking.
+// It returns [ErrWouldBlock] if the lock is held by another process.
+// The lock is released once the file is closed.
+func Lock(file *os.File) error {
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return ErrWouldBlock
+		}
+
+		return err //nolint:wrapcheck // The caller wraps it with the purpose of the lock.
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package listener tags the connections accepted by the listener,
+// so the server can recognize which listener the connection comes from,
+// even if the listener listens on the wildcard address, e.g. `:8080`.
+package listener
+
+import "net"
+
+// Tag wraps the listener so the local address of each accepted connection is tagged,
+// which can be checked by [Tagged], e.g. with http.LocalAddrContextKey or peer.Peer.LocalAddr of gRPC.
+func Tag(listener net.Listener) net.Listener {
+	return taggedListener{Listener: listener}
+}
+
+// Tagged returns true if the local address is of the connection accepted by the listener wrapped by [Tag].
+func Tagged(addr net.Addr) bool {
+	_, ok := addr.(taggedAddr)
+
+	return ok
+}
+
+type (
+	taggedListener struct {
+		net.Listener
+	}
+	taggedConn struct {
+		net.Conn
+	}
+	taggedAddr struct {
+		net.Addr
+	}
+)
+
+func (t taggedListener) Accept() (net.Conn, error) {
+	conn, err := t.Listener.Accept()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return taggedConn{Conn: conn}, nil
+}
+
+func (t taggedConn) LocalAddr() net.Addr {
+	return taggedAddr{Addr: t.Conn.LocalAddr()}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package listener_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/nil-go/nilgo/internal/assert"
+	"github.com/nil-go/nilgo/internal/listener"
+)
+
+func TestTag(t *testing.T) {
+	t.Parallel()
+
+	tcpListener, err := net.Listen("tcp", ":0") // Wildcard address.
+	assert.NoError(t, err)
+	tagged := listener.Tag(tcpListener)
+	defer func() { _ = tagged.Close() }()
+	assert.Equal(t, tcpListener.Addr(), tagged.Addr())
+
+	go func() {
+		conn, err := net.Dial("tcp", tagged.Addr().String())
+		if err == nil {
+			_ = conn.Close()
+		}
+	}()
+	conn, err := tagged.Accept()
+	assert.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	assert.Equal(t, true, listener.Tagged(conn.LocalAddr()))
+	assert.Equal(t, false, listener.Tagged(tcpListener.Addr()))
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package recovery recovers the panic from runs as errors.
+package recovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// ErrPanic is wrapped by the error recovered from panic.
+var ErrPanic = errors.New("panic")
+
+// Recover executes the run, and recovers the panic from the run as an error which wraps [ErrPanic].
+// The panic is logged with the given attributes and the stack.
+func Recover(ctx context.Context, run func(context.Context) error, attrs ...slog.Attr) (err error) { //nolint:nonamedreturns
+	defer func() {
+		if r := recover(); r != nil {
+			err = logPanic(ctx, r, attrs)
+		}
+	}()
+
+	return run(ctx)
+}
+
+func logPanic(ctx context.Context, message any, attrs []slog.Attr) error {
+	err, ok := message.(error)
+	if !ok {
+		err = fmt.Errorf("%v", message) //nolint:err113
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(4, pcs[:]) //nolint:mnd // Skip runtime.Callers, this function, the deferred function and panic.
+	record := slog.NewRecord(time.Now(), slog.LevelError, "Panic Recovered", pcs[0])
+	record.AddAttrs(attrs...)
+	record.AddAttrs(slog.Any("error", err), slog.String("stack", string(debug.Stack())))
+	_ = slog.Default().Handler().Handle(ctx, record) // Ignore error: It's fine to lose log.
+
+	return fmt.Errorf("%w: %w", ErrPanic, err)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package recovery_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/nil-go/nilgo/internal/assert"
+	"github.com/nil-go/nilgo/internal/recovery"
+)
+
+//nolint:paralleltest // It changes the default logger.
+func TestRecover(t *testing.T) {
+	testcases := []struct {
+		description string
+		run         func(context.Context) error
+		err         string
+		log         string
+	}{
+		{
+			description: "no panic",
+			run:         func(context.Context) error { return errors.New("run error") },
+			err:         "run error",
+		},
+		{
+			description: "panic with error",
+			run:         func(context.Context) error { panic(errors.New("boom")) },
+			err:         "panic: boom",
+			log:         `level=ERROR msg="Panic Recovered" run=test error=boom stack=`,
+		},
+		{
+			description: "panic with value",
+			run:         func(context.Context) error { panic("boom") },
+			err:         "panic: boom",
+			log:         `level=ERROR msg="Panic Recovered" run=test error=boom stack=`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			logger := slog.Default()
+			defer slog.SetDefault(logger)
+			slog.SetDefault(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+					if len(groups) == 0 && attr.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+
+					return attr
+				},
+			})))
+
+			err := recovery.Recover(context.Background(), testcase.run, slog.String("run", "test"))
+			assert.EqualError(t, err, testcase.err)
+			assert.Equal(t, testcase.log != "", errors.Is(err, recovery.ErrPanic))
+			if testcase.log == "" {
+				assert.Equal(t, "", buf.String())
+			} else {
+				assert.Equal(t, true, strings.HasPrefix(buf.String(), testcase.log))
+				assert.Equal(t, true, strings.Contains(buf.String(), "recovery_test.go"))
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nil-go/nilgo/internal/flock"
+)
+
+// FileLock is a Lock with an exclusive flock on the file, which elects the leader among the processes
+// on a single host. The lease does not expire while the holder process is alive,
+// and it's released by the OS once the process exits.
+//
+// To create a new FileLock, call [NewFileLock].
+type FileLock struct {
+	path string
+
+	mutex  sync.Mutex
+	file   *os.File
+	holder string
+}
+
+// NewFileLock creates a new FileLock on the file at the given path.
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path}
+}
+
+func (f *FileLock) Acquire(_ context.Context, holder string, _ time.Duration) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file != nil {
+		return f.holder == holder, nil
+	}
+
+	file, err := os.OpenFile(f.path, os.O_RDWR|os.O_CREATE, 0o644) //nolint:gosec,mnd
+	if err != nil {
+		return false, fmt.Errorf("open lock file: %w", err)
+	}
+	if err := flock.Lock(file); err != nil {
+		_ = file.Close()
+		if errors.Is(err, flock.ErrWouldBlock) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("lock file: %w", err)
+	}
+	f.file = file
+	f.holder = holder
+
+	return true, nil
+}
+
+func (f *FileLock) Release(_ context.Context, holder string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil || f.holder != holder {
+		return nil
+	}
+	defer func() {
+		f.file = nil
+		f.holder = ""
+	}()
+
+	// Closing the file also releases the lock.
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("close lock file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package leader provides lease-based leader election for runs,
+// so only one of the replicas executes the run at a time.
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// Lock is the backend which stores the lease of leader election,
+// e.g. [MemoryLock], [FileLock] or [SQLLock].
+type Lock interface {
+	// Acquire acquires the lease for the holder with the ttl, or renews it if the holder already holds it.
+	// It returns false if another holder holds the lease which has not expired.
+	Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error)
+	// Release releases the lease if the holder holds it.
+	Release(ctx context.Context, holder string) error
+}
+
+// Run wraps the run with leader election on the lock, so the run only starts
+// while the replica holds the lease. The context of the run is canceled once the lease is lost,
+// and the replica campaigns for the lease again after the run returns.
+//
+// It releases the lease and returns once the run returns by itself or ctx is done.
+func Run(lock Lock, run func(context.Context) error, opts ...Option) func(context.Context) error {
+	option := &options{}
+	for _, opt := range opts {
+		opt(option)
+	}
+	if option.holder == "" {
+		hostname, _ := os.Hostname()
+		option.holder = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	if option.ttl <= 0 {
+		option.ttl = defaultTTL
+	}
+	if option.renewInterval <= 0 || option.renewInterval >= option.ttl {
+		option.renewInterval = option.ttl / 3 //nolint:mnd
+	}
+	election := &election{options: *option, lock: lock}
+
+	return func(ctx context.Context) error {
+		for {
+			if !election.campaign(ctx) {
+				return nil
+			}
+			if lost, err := election.lead(ctx, run); !lost {
+				return err
+			}
+		}
+	}
+}
+
+type election struct {
+	options
+	lock Lock
+}
+
+// campaign blocks until the lease is acquired, and returns false if ctx is done before that.
+func (e *election) campaign(ctx context.Context) bool {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+		}
+
+		acquired, err := e.lock.Acquire(ctx, e.holder, e.ttl)
+		if err != nil && ctx.Err() == nil {
+			slog.LogAttrs(ctx, slog.LevelWarn, "Fail to acquire leader lease.", e.attrs(slog.Any("error", err))...)
+		}
+		if acquired {
+			slog.LogAttrs(ctx, slog.LevelInfo, "Leadership has been acquired.", e.attrs()...)
+
+			return true
+		}
+		timer.Reset(e.renewInterval)
+	}
+}
+
+// lead executes the run while renewing the lease, and returns true if the lease is lost.
+func (e *election) lead(ctx context.Context, run func(context.Context) error) (bool, error) {
+	leaderCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- run(leaderCtx)
+	}()
+
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+	renewed := time.Now()
+	for {
+		select {
+		case err := <-done:
+			if releaseErr := e.lock.Release(context.WithoutCancel(ctx), e.holder); releaseErr != nil {
+				slog.LogAttrs(ctx, slog.LevelWarn, "Fail to release leader lease.", e.attrs(slog.Any("error", releaseErr))...)
+			} else {
+				slog.LogAttrs(ctx, slog.LevelInfo, "Leadership has been released.", e.attrs()...)
+			}
+
+			return false, err
+		case <-ticker.C:
+		}
+
+		acquired, err := e.lock.Acquire(ctx, e.holder, e.ttl)
+		switch {
+		case acquired:
+			renewed = time.Now()
+
+			continue
+		case ctx.Err() != nil:
+			// The renewal fails due to shutdown rather than losing the lease,
+			// so stop renewing, and release the lease once the run returns.
+			ticker.Stop()
+
+			continue
+		case err != nil && time.Since(renewed)+e.renewInterval < e.ttl:
+			// Retry while the lease has not expired before the next renewal.
+			slog.LogAttrs(ctx, slog.LevelWarn, "Fail to renew leader lease.", e.attrs(slog.Any("error", err))...)
+
+			continue
+		}
+
+		attrs := e.attrs()
+		if err != nil {
+			attrs = append(attrs, slog.Any("error", err))
+		}
+		slog.LogAttrs(ctx, slog.LevelWarn, "Leadership has been lost.", attrs...)
+		cancel(errLeaseLost)
+		<-done
+
+		return true, nil
+	}
+}
+
+func (e *election) attrs(attrs ...slog.Attr) []slog.Attr {
+	return append([]slog.Attr{slog.String("election", e.name), slog.String("holder", e.holder)}, attrs...)
+}
+
+const defaultTTL = 15 * time.Second
+
+var errLeaseLost = errors.New("leader lease lost")
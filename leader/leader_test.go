@@ -0,0 +1,166 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package leader_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nil-go/nilgo/internal/assert"
+	"github.com/nil-go/nilgo/leader"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	lock := leader.NewMemoryLock()
+	var leaders, maxLeaders atomic.Int32
+	started := make(chan string, 2)
+	run := func(holder string) func(context.Context) error {
+		return leader.Run(lock, func(ctx context.Context) error {
+			current := leaders.Add(1)
+			defer leaders.Add(-1)
+			if current > maxLeaders.Load() {
+				maxLeaders.Store(current)
+			}
+			started <- holder
+			<-ctx.Done()
+
+			return nil
+		}, leader.WithName("job"), leader.WithHolder(holder),
+			leader.WithTTL(100*time.Millisecond), leader.WithRenewInterval(10*time.Millisecond))
+	}
+
+	var waitGroup sync.WaitGroup
+	cancels := map[string]context.CancelFunc{}
+	for _, holder := range []string{"a", "b"} {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cancels[holder] = cancel
+
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			assert.NoError(t, run(holder)(ctx))
+		}()
+	}
+
+	first := <-started
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), leaders.Load())
+
+	cancels[first]()
+	second := <-started
+	assert.Equal(t, true, first != second)
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	waitGroup.Wait()
+	assert.Equal(t, int32(1), maxLeaders.Load())
+}
+
+func TestRun_lost(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lock := &flakyLock{Lock: leader.NewMemoryLock()}
+	var count atomic.Int32
+	err := leader.Run(lock, func(ctx context.Context) error {
+		if count.Add(1) == 1 {
+			lock.lost.Store(true)
+			<-ctx.Done()
+			lock.lost.Store(false)
+
+			return ctx.Err()
+		}
+		cancel()
+
+		return nil
+	}, leader.WithTTL(50*time.Millisecond), leader.WithRenewInterval(10*time.Millisecond))(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), count.Load())
+}
+
+func TestRun_error(t *testing.T) {
+	t.Parallel()
+
+	lock := leader.NewMemoryLock()
+	err := leader.Run(lock, func(context.Context) error {
+		return errors.New("run error")
+	}, leader.WithHolder("a"))(context.Background())
+	assert.EqualError(t, err, "run error")
+
+	acquired, err := lock.Acquire(context.Background(), "b", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, true, acquired)
+}
+
+func TestRun_renewError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lock := &flakyLock{Lock: leader.NewMemoryLock()}
+	var count atomic.Int32
+	err := leader.Run(lock, func(ctx context.Context) error {
+		count.Add(1)
+		lock.err.Store(true)
+		time.Sleep(30 * time.Millisecond)
+		lock.err.Store(false)
+		cancel()
+		<-ctx.Done()
+
+		return nil
+	}, leader.WithTTL(100*time.Millisecond), leader.WithRenewInterval(10*time.Millisecond))(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), count.Load())
+}
+
+func TestRun_shutdown(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lock := &flakyLock{Lock: leader.NewMemoryLock()}
+	err := leader.Run(lock, func(ctx context.Context) error {
+		cancel()
+		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond) // Renew while the run is stopping, longer than the ttl.
+
+		return errors.New("stop error")
+	}, leader.WithHolder("a"), leader.WithTTL(50*time.Millisecond), leader.WithRenewInterval(10*time.Millisecond))(ctx)
+	// The error of the run is returned, since the lease is not lost on shutdown.
+	assert.EqualError(t, err, "stop error")
+}
+
+// flakyLock reports the lease as held by others while lost is set, or fails while err is set or ctx is done.
+type flakyLock struct {
+	leader.Lock
+
+	lost atomic.Bool
+	err  atomic.Bool
+}
+
+func (f *flakyLock) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	switch {
+	case f.lost.Load():
+		return false, nil
+	case f.err.Load():
+		return false, errors.New("acquire error")
+	case ctx.Err() != nil:
+		return false, ctx.Err()
+	default:
+		return f.Lock.Acquire(ctx, holder, ttl)
+	}
+}
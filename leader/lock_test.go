@@ -0,0 +1,177 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package leader_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nil-go/nilgo/internal/assert"
+	"github.com/nil-go/nilgo/leader"
+)
+
+func TestLock(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		locks       func(t *testing.T) (leader.Lock, leader.Lock)
+		expirable   bool
+	}{
+		{
+			description: "memory",
+			locks: func(*testing.T) (leader.Lock, leader.Lock) {
+				lock := leader.NewMemoryLock()
+
+				return lock, lock
+			},
+			expirable: true,
+		},
+		{
+			description: "file",
+			locks: func(t *testing.T) (leader.Lock, leader.Lock) {
+				t.Helper()
+
+				path := filepath.Join(t.TempDir(), "leader.lock")
+
+				return leader.NewFileLock(path), leader.NewFileLock(path)
+			},
+		},
+		{
+			description: "sql",
+			locks: func(*testing.T) (leader.Lock, leader.Lock) {
+				db := sql.OpenDB(&leaseDB{leases: map[string]lease{}})
+
+				return leader.NewSQLLock(db, "job"),
+					leader.NewSQLLock(db, "job", leader.WithPlaceholder(func(int) string { return "?" }))
+			},
+			expirable: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			lockA, lockB := testcase.locks(t)
+			acquire := func(lock leader.Lock, holder string, ttl time.Duration) bool {
+				acquired, err := lock.Acquire(ctx, holder, ttl)
+				assert.NoError(t, err)
+
+				return acquired
+			}
+
+			assert.Equal(t, true, acquire(lockA, "a", time.Minute))
+			assert.Equal(t, false, acquire(lockB, "b", time.Minute))
+			assert.Equal(t, true, acquire(lockA, "a", time.Minute))
+			assert.NoError(t, lockB.Release(ctx, "b"))
+			assert.Equal(t, false, acquire(lockB, "b", time.Minute))
+			assert.NoError(t, lockA.Release(ctx, "a"))
+			assert.Equal(t, true, acquire(lockB, "b", 10*time.Millisecond))
+
+			if testcase.expirable {
+				time.Sleep(20 * time.Millisecond)
+				assert.Equal(t, true, acquire(lockA, "a", time.Minute))
+			}
+		})
+	}
+}
+
+// leaseDB is a fake database/sql driver which only supports the queries of SQLLock.
+type (
+	leaseDB struct {
+		mutex  sync.Mutex
+		leases map[string]lease
+	}
+	lease struct {
+		holder string
+		expiry int64
+	}
+	leaseStmt struct {
+		db    *leaseDB
+		query string
+	}
+	leaseRows struct {
+		values []string
+	}
+)
+
+func (l *leaseDB) Connect(context.Context) (driver.Conn, error) { return l, nil }
+func (l *leaseDB) Driver() driver.Driver                        { return nil }
+func (l *leaseDB) Close() error                                 { return nil }
+func (l *leaseDB) Begin() (driver.Tx, error)                    { return nil, errors.ErrUnsupported }
+func (l *leaseDB) Prepare(query string) (driver.Stmt, error) {
+	return leaseStmt{db: l, query: query}, nil
+}
+
+func (leaseStmt) Close() error  { return nil }
+func (leaseStmt) NumInput() int { return -1 }
+
+func (s leaseStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mutex.Lock()
+	defer s.db.mutex.Unlock()
+
+	switch {
+	case strings.HasPrefix(s.query, "UPDATE leader_leases SET holder = ?, expiry = ? WHERE name = ?"):
+		current, exist := s.db.leases[args[2].(string)]
+		if !exist || current.holder != args[3].(string) && current.expiry >= args[4].(int64) {
+			return driver.RowsAffected(0), nil
+		}
+		s.db.leases[args[2].(string)] = lease{holder: args[0].(string), expiry: args[1].(int64)}
+
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(s.query, "INSERT INTO leader_leases (name, holder, expiry) VALUES (?, ?, ?)"):
+		if _, exist := s.db.leases[args[0].(string)]; exist {
+			return nil, errors.New("UNIQUE constraint failed")
+		}
+		s.db.leases[args[0].(string)] = lease{holder: args[1].(string), expiry: args[2].(int64)}
+
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(s.query, "DELETE FROM leader_leases WHERE name = ? AND holder = ?"):
+		if current, exist := s.db.leases[args[0].(string)]; exist && current.holder == args[1].(string) {
+			delete(s.db.leases, args[0].(string))
+
+			return driver.RowsAffected(1), nil
+		}
+
+		return driver.RowsAffected(0), nil
+	default:
+		return nil, errors.New("unsupported query: " + s.query)
+	}
+}
+
+func (s leaseStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mutex.Lock()
+	defer s.db.mutex.Unlock()
+
+	if s.query != "SELECT holder FROM leader_leases WHERE name = ?" {
+		return nil, errors.New("unsupported query: " + s.query)
+	}
+	rows := &leaseRows{}
+	if current, exist := s.db.leases[args[0].(string)]; exist {
+		rows.values = append(rows.values, current.holder)
+	}
+
+	return rows, nil
+}
+
+func (*leaseRows) Columns() []string { return []string{"holder"} }
+func (*leaseRows) Close() error      { return nil }
+func (r *leaseRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+
+	return nil
+}
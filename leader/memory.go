@@ -0,0 +1,50 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLock is a Lock in memory, which is shared by the elections in the same process, e.g. in tests.
+//
+// To create a new MemoryLock, call [NewMemoryLock].
+type MemoryLock struct {
+	mutex  sync.Mutex
+	holder string
+	expiry time.Time
+}
+
+// NewMemoryLock creates a new MemoryLock.
+func NewMemoryLock() *MemoryLock {
+	return &MemoryLock{}
+}
+
+func (m *MemoryLock) Acquire(_ context.Context, holder string, ttl time.Duration) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	if m.holder != "" && m.holder != holder && now.Before(m.expiry) {
+		return false, nil
+	}
+	m.holder = holder
+	m.expiry = now.Add(ttl)
+
+	return true, nil
+}
+
+func (m *MemoryLock) Release(_ context.Context, holder string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.holder == holder {
+		m.holder = ""
+		m.expiry = time.Time{}
+	}
+
+	return nil
+}
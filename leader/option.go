@@ -0,0 +1,76 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package leader
+
+import "time"
+
+// WithName provides the name of the election, which is used in logs.
+func WithName(name string) Option {
+	return func(options *options) {
+		options.name = name
+	}
+}
+
+// WithHolder provides the identity of the replica which holds the lease.
+// It must be unique among the replicas.
+//
+// By default, it's `${hostname}-${pid}`.
+func WithHolder(holder string) Option {
+	return func(options *options) {
+		options.holder = holder
+	}
+}
+
+// WithTTL provides the duration of the lease, after which another replica can take over
+// if the leader fails to renew it.
+//
+// By default, it's 15 seconds.
+func WithTTL(ttl time.Duration) Option {
+	return func(options *options) {
+		options.ttl = ttl
+	}
+}
+
+// WithRenewInterval provides the interval of renewing the lease while holding it,
+// and retrying to acquire it while not holding it. It must be shorter than the ttl.
+//
+// By default, it's one third of the ttl.
+func WithRenewInterval(interval time.Duration) Option {
+	return func(options *options) {
+		options.renewInterval = interval
+	}
+}
+
+// WithTable provides the name of the table which stores the leases.
+//
+// By default, it's `leader_leases`.
+func WithTable(table string) SQLOption {
+	return func(lock *SQLLock) {
+		lock.table = table
+	}
+}
+
+// WithPlaceholder provides the placeholder of the query argument with the 1-based index,
+// e.g. `$1` for PostgreSQL.
+//
+// By default, it's `?`.
+func WithPlaceholder(placeholder func(index int) string) SQLOption {
+	return func(lock *SQLLock) {
+		lock.placeholder = placeholder
+	}
+}
+
+type (
+	// Option configures the leader election with optional parameters.
+	Option  func(*options)
+	options struct {
+		name          string
+		holder        string
+		ttl           time.Duration
+		renewInterval time.Duration
+	}
+
+	// SQLOption configures the SQLLock with optional parameters.
+	SQLOption func(*SQLLock)
+)
@@ -0,0 +1,109 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SQLLock is a Lock with a row in the SQL table, which elects the leader among the replicas sharing the database.
+// The table has to be created in advance, e.g.
+//
+//	CREATE TABLE leader_leases (
+//		name   VARCHAR(255) PRIMARY KEY,
+//		holder VARCHAR(255) NOT NULL,
+//		expiry BIGINT       NOT NULL -- Unix time in milliseconds.
+//	)
+//
+// The expiry is based on the clock of the replicas, so the clock skew should be much less than the ttl.
+//
+// To create a new SQLLock, call [NewSQLLock].
+type SQLLock struct {
+	db          *sql.DB
+	name        string
+	table       string
+	placeholder func(int) string
+}
+
+// NewSQLLock creates a new SQLLock for the lease with the given name in the database.
+func NewSQLLock(db *sql.DB, name string, opts ...SQLOption) *SQLLock {
+	lock := &SQLLock{db: db, name: name}
+	for _, opt := range opts {
+		opt(lock)
+	}
+	if lock.table == "" {
+		lock.table = "leader_leases"
+	}
+	if lock.placeholder == nil {
+		lock.placeholder = func(int) string { return "?" }
+	}
+
+	return lock
+}
+
+func (s *SQLLock) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	expiry := now.Add(ttl).UnixMilli()
+
+	// Take over the lease if it's held by the holder or it has expired.
+	result, err := s.db.ExecContext(ctx,
+		s.query("UPDATE %s SET holder = %s, expiry = %s WHERE name = %s AND (holder = %s OR expiry < %s)"),
+		holder, expiry, s.name, holder, now.UnixMilli(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("update lease: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return false, fmt.Errorf("update lease: %w", err)
+	} else if rows > 0 {
+		return true, nil
+	}
+
+	// Create the lease if it does not exist.
+	_, insertErr := s.db.ExecContext(ctx,
+		s.query("INSERT INTO %s (name, holder, expiry) VALUES (%s, %s, %s)"),
+		s.name, holder, expiry,
+	)
+	if insertErr == nil {
+		return true, nil
+	}
+	// The insert fails with constraint violation if another holder holds the lease,
+	// which differs in databases, so it checks the existence of the lease instead.
+	var current string
+	err = s.db.QueryRowContext(ctx, s.query("SELECT holder FROM %s WHERE name = %s"), s.name).Scan(&current)
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return false, fmt.Errorf("insert lease: %w", insertErr)
+	default:
+		return false, fmt.Errorf("query lease: %w", err)
+	}
+}
+
+func (s *SQLLock) Release(ctx context.Context, holder string) error {
+	if _, err := s.db.ExecContext(ctx,
+		s.query("DELETE FROM %s WHERE name = %s AND holder = %s"),
+		s.name, holder,
+	); err != nil {
+		return fmt.Errorf("delete lease: %w", err)
+	}
+
+	return nil
+}
+
+// query formats the query with the table name and the placeholders of arguments.
+func (s *SQLLock) query(format string) string {
+	args := []any{s.table}
+	for i := 1; i < strings.Count(format, "%s"); i++ {
+		args = append(args, s.placeholder(i))
+	}
+
+	return fmt.Sprintf(format, args...)
+}
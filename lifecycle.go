@@ -0,0 +1,218 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// State is the lifecycle state of the Runner.
+type State int32
+
+const (
+	// StateIdle means the Runner has not started yet.
+	StateIdle State = iota
+	// StateStarting means the Runner is waiting for pre runs to start and start gates to open.
+	StateStarting
+	// StateRunning means the Runner is executing main runs.
+	StateRunning
+	// StateStopping means the Runner is waiting for stop gates to open.
+	StateStopping
+	// StateDraining means the Runner is waiting for main runs to return.
+	StateDraining
+	// StateFinishing means the Runner is waiting for post runs to complete.
+	StateFinishing
+	// StateStopped means the Runner has completed the execution.
+	StateStopped
+)
+
+func (s State) String() string {
+	switch s {
+	case StateIdle:
+		return "idle"
+	case StateStarting:
+		return "starting"
+	case StateRunning:
+		return "running"
+	case StateStopping:
+		return "stopping"
+	case StateDraining:
+		return "draining"
+	case StateFinishing:
+		return "finishing"
+	case StateStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// EventKind is the kind of lifecycle event of the Runner.
+type EventKind int
+
+const (
+	// EventStateChanged is emitted when the Runner enters a new State.
+	EventStateChanged EventKind = iota
+	// EventRunStarted is emitted when a run starts.
+	EventRunStarted
+	// EventRunExited is emitted when a run returns, with the error if it fails.
+	EventRunExited
+	// EventSignal is emitted when the Runner receives an OS signal.
+	EventSignal
+	// EventReloadStarted is emitted when the Runner starts reloading.
+	EventReloadStarted
+	// EventReloadFinished is emitted when the Runner completes reloading, with the error if it fails.
+	EventReloadFinished
+)
+
+// Event is the lifecycle event of the Runner.
+type Event struct {
+	Kind EventKind
+	Time time.Time
+	// State is the current state of the Runner.
+	State State
+	// Phase and Run are the phase and name of the run for EventRunStarted and EventRunExited.
+	Phase Phase
+	Run   string
+	// Err is the error returned by the run for EventRunExited,
+	// or the error of reload hooks for EventReloadFinished.
+	Err error
+	// Signal is the received OS signal for EventSignal.
+	Signal os.Signal
+}
+
+// State returns the current lifecycle state of the Runner.
+func (r Runner) State() State {
+	if r.lifecycle == nil {
+		return StateIdle
+	}
+
+	return State(r.lifecycle.state.Load())
+}
+
+// Subscribe registers the handler to receive lifecycle events of the Runner,
+// and returns the function to unregister it.
+//
+// The handler is called synchronously in the order of events, one event at a time,
+// so it must be non-blocking and usually completes instantly.
+// It can unregister itself, but must not trigger other events, e.g. calling Runner.Reload.
+func (r Runner) Subscribe(handler func(Event)) func() {
+	if r.lifecycle == nil || handler == nil {
+		return func() {}
+	}
+
+	return r.lifecycle.subscribe(handler)
+}
+
+// FromContext returns the Runner which executes the run with the given context.
+func FromContext(ctx context.Context) (Runner, bool) {
+	runner, ok := ctx.Value(runnerKey{}).(Runner)
+
+	return runner, ok
+}
+
+type (
+	runnerKey struct{}
+	lifecycle struct {
+		state atomic.Int32
+
+		mutex       sync.RWMutex
+		subscribers map[*func(Event)]struct{}
+		emission    sync.Mutex
+
+		transition sync.Mutex
+		span       trace.Span
+		spanStart  time.Time
+	}
+)
+
+func (l *lifecycle) subscribe(handler func(Event)) func() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.subscribers == nil {
+		l.subscribers = make(map[*func(Event)]struct{})
+	}
+	key := &handler
+	l.subscribers[key] = struct{}{}
+
+	return func() {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		delete(l.subscribers, key)
+	}
+}
+
+func (l *lifecycle) emit(event Event) {
+	// Serialize emission from different goroutines, so handlers receive events in order.
+	l.emission.Lock()
+	defer l.emission.Unlock()
+
+	event.Time = time.Now()
+	event.State = State(l.state.Load())
+
+	// Call handlers without the lock, so the handler can unregister itself.
+	l.mutex.RLock()
+	handlers := make([]func(Event), 0, len(l.subscribers))
+	for handler := range l.subscribers {
+		handlers = append(handlers, *handler)
+	}
+	l.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// transit moves the Runner into the given state, emits EventStateChanged,
+// and records the span and duration of the previous state.
+func (l *lifecycle) transit(ctx context.Context, state State) {
+	l.transition.Lock()
+	defer l.transition.Unlock()
+
+	previous := State(l.state.Swap(int32(state)))
+	if previous == state {
+		return
+	}
+
+	now := time.Now()
+	if previous != StateIdle && previous != StateStopped {
+		if histogram, err := stateHistogram(); err == nil {
+			histogram.Record(ctx, now.Sub(l.spanStart).Seconds(),
+				metric.WithAttributes(attribute.String("state", previous.String())),
+			)
+		}
+	}
+	if l.span != nil {
+		l.span.End(trace.WithTimestamp(now))
+		l.span = nil
+	}
+	if state != StateRunning && state != StateStopped {
+		_, l.span = otel.Tracer(meterName).Start(ctx, "nilgo.runner."+state.String(), trace.WithTimestamp(now))
+	}
+	l.spanStart = now
+
+	l.emit(Event{Kind: EventStateChanged})
+}
+
+// stateHistogram creates the histogram of state durations once, which follows the meter provider set later
+// since the global meter provider delegates the instruments to it.
+//
+//nolint:gochecknoglobals
+var stateHistogram = sync.OnceValues(func() (metric.Float64Histogram, error) {
+	return otel.Meter(meterName).Float64Histogram("nilgo.runner.state.duration", //nolint:wrapcheck
+		metric.WithDescription("The duration of the Runner in each lifecycle state."),
+		metric.WithUnit("s"),
+	)
+})
@@ -0,0 +1,189 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package log provides dynamic level control for slog.Handler,
+// so the verbosity of a live service can be changed per logger group or source package,
+// and reverts automatically after an optional TTL.
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Level is the log level for the logger group or source package with the given name.
+type Level struct {
+	// Name is the name of the logger group, e.g. `http.request` for slog.Logger.WithGroup("http").WithGroup("request"),
+	// or the path of the source package, e.g. `github.com/nil-go/nilgo`.
+	// It also applies to the nested groups and sub packages. The empty name is for all logs.
+	Name  string
+	Level slog.Level
+	// Expiry is the time when the level reverts. It's zero if the level does not expire.
+	Expiry time.Time
+}
+
+func (l Level) MarshalJSON() ([]byte, error) {
+	level := struct {
+		Name   string     `json:"name"`
+		Level  slog.Level `json:"level"`
+		Expiry *time.Time `json:"expiry,omitempty"`
+	}{Name: l.Name, Level: l.Level}
+	if !l.Expiry.IsZero() {
+		level.Expiry = &l.Expiry
+	}
+
+	return json.Marshal(level) //nolint:wrapcheck
+}
+
+// Control holds the log levels which are changed at runtime.
+//
+// To create a new Control, call [NewControl].
+type Control struct {
+	mutex  sync.Mutex
+	levels atomic.Pointer[levels]
+}
+
+// NewControl creates a new Control.
+func NewControl() *Control {
+	control := &Control{}
+	control.levels.Store(&levels{})
+
+	return control
+}
+
+// Set sets the log level for the logger group or source package with the given name,
+// which reverts after the ttl if it's positive.
+func (c *Control) Set(name string, level slog.Level, ttl time.Duration) {
+	var expiry time.Time
+	if ttl > 0 {
+		expiry = time.Now().Add(ttl)
+	}
+	c.update(func(values map[string]Level) {
+		values[name] = Level{Name: name, Level: level, Expiry: expiry}
+	})
+	slog.LogAttrs(context.Background(), slog.LevelInfo, "Log level has been changed.",
+		slog.String("name", name),
+		slog.Any("level", level),
+		slog.Duration("ttl", ttl),
+	)
+}
+
+// Reset reverts the log level for the logger group or source package with the given name.
+func (c *Control) Reset(name string) {
+	c.update(func(values map[string]Level) {
+		delete(values, name)
+	})
+	slog.LogAttrs(context.Background(), slog.LevelInfo, "Log level has been reset.", slog.String("name", name))
+}
+
+// Level returns the log level for the logger group or source package with the given name,
+// which is the level of the longest matched name. It returns false if no level applies.
+func (c *Control) Level(name string) (slog.Level, bool) {
+	return c.levels.Load().match(time.Now(), name)
+}
+
+// Levels returns all log levels which have not expired, ordered by name.
+func (c *Control) Levels() []Level {
+	now := time.Now()
+	values := make([]Level, 0, len(c.levels.Load().values))
+	for _, level := range c.levels.Load().values {
+		if !level.expired(now) {
+			values = append(values, level)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+
+	return values
+}
+
+func (c *Control) update(change func(map[string]Level)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	current := c.levels.Load()
+	values := make(map[string]Level, len(current.values)+1)
+	for name, level := range current.values {
+		if !level.expired(now) {
+			values[name] = level
+		}
+	}
+	change(values)
+
+	next := &levels{values: values}
+	for _, level := range values {
+		if !next.hasMin || level.Level < next.min {
+			next.min = level.Level
+			next.hasMin = true
+		}
+	}
+	c.levels.Store(next)
+}
+
+// levels is an immutable snapshot of log levels, so it can be read without lock while logging.
+type levels struct {
+	values map[string]Level
+	min    slog.Level
+	hasMin bool
+}
+
+func (l *levels) match(now time.Time, names ...string) (slog.Level, bool) {
+	var (
+		matched Level
+		found   bool
+	)
+	for _, level := range l.values {
+		if level.expired(now) || found && len(level.Name) <= len(matched.Name) {
+			continue
+		}
+		for _, name := range names {
+			if level.matches(name) {
+				matched, found = level, true
+
+				break
+			}
+		}
+	}
+
+	return matched.Level, found
+}
+
+func (l Level) expired(now time.Time) bool {
+	return !l.Expiry.IsZero() && now.After(l.Expiry)
+}
+
+func (l Level) matches(name string) bool {
+	switch {
+	case l.Name == "" || l.Name == name:
+		return true
+	case !strings.HasPrefix(name, l.Name):
+		return false
+	default:
+		next := name[len(l.Name)]
+
+		return next == '.' || next == '/'
+	}
+}
+
+// Default returns the default Control, which is used by nilgo.WithLogger.
+func Default() *Control {
+	return defaultControl
+}
+
+// Set sets the log level with the given name in the default Control. See [Control.Set] for details.
+func Set(name string, level slog.Level, ttl time.Duration) {
+	defaultControl.Set(name, level, ttl)
+}
+
+// Reset reverts the log level with the given name in the default Control. See [Control.Reset] for details.
+func Reset(name string) {
+	defaultControl.Reset(name)
+}
+
+var defaultControl = NewControl() //nolint:gochecknoglobals
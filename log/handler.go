@@ -0,0 +1,102 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Handler wraps slog.Handler with dynamic level control by the Control.
+// For each record, it applies the level of the longest matched logger group or source package,
+// or falls back to the wrapped handler if no level applies.
+//
+// To create a new Handler, call [NewHandler].
+type Handler struct {
+	handler slog.Handler
+	control *Control
+	group   string
+}
+
+// NewHandler creates a new Handler which wraps the given slog.Handler with the Control.
+// It uses the default Control if the control is nil.
+func NewHandler(handler slog.Handler, control *Control) Handler {
+	if control == nil {
+		control = defaultControl
+	}
+
+	return Handler{handler: handler, control: control}
+}
+
+func (h Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	levels := h.control.levels.Load()
+	if levels.hasMin && level >= levels.min {
+		// It has to check the source package of the record in Handle.
+		return true
+	}
+
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h Handler) Handle(ctx context.Context, record slog.Record) error {
+	levels := h.control.levels.Load()
+	if levels.hasMin {
+		names := []string{h.group}
+		if pkg := sourcePackage(record.PC); pkg != "" {
+			names = append(names, pkg)
+		}
+		if level, ok := levels.match(time.Now(), names...); ok {
+			if record.Level < level {
+				return nil
+			}
+
+			return h.handler.Handle(ctx, record) //nolint:wrapcheck
+		}
+		if !h.handler.Enabled(ctx, record.Level) {
+			return nil
+		}
+	}
+
+	return h.handler.Handle(ctx, record) //nolint:wrapcheck
+}
+
+func (h Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h.handler = h.handler.WithAttrs(attrs)
+
+	return h
+}
+
+func (h Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h.handler = h.handler.WithGroup(name)
+	if h.group == "" {
+		h.group = name
+	} else {
+		h.group += "." + name
+	}
+
+	return h
+}
+
+// sourcePackage returns the package path of the function which the pc belongs to,
+// e.g. `github.com/nil-go/nilgo` for `github.com/nil-go/nilgo.Runner.Run`.
+func sourcePackage(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	function := frame.Function
+	slash := strings.LastIndexByte(function, '/') + 1
+	if dot := strings.IndexByte(function[slash:], '.'); dot >= 0 {
+		return function[:slash+dot]
+	}
+
+	return function
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package log_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/nil-go/nilgo/internal/assert"
+	"github.com/nil-go/nilgo/log"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		levels      []log.Level
+		log         func(*slog.Logger)
+		expected    string
+	}{
+		{
+			description: "no level",
+			log: func(logger *slog.Logger) {
+				logger.Debug("debug")
+				logger.Info("info")
+			},
+			expected: "level=INFO msg=info\n",
+		},
+		{
+			description: "global level",
+			levels:      []log.Level{{Level: slog.LevelDebug}},
+			log: func(logger *slog.Logger) {
+				logger.Debug("debug")
+			},
+			expected: "level=DEBUG msg=debug\n",
+		},
+		{
+			description: "group level",
+			levels:      []log.Level{{Name: "http", Level: slog.LevelError}},
+			log: func(logger *slog.Logger) {
+				logger.WithGroup("http").Info("http")
+				logger.WithGroup("http").WithGroup("request").Warn("request")
+				logger.WithGroup("grpc").Info("grpc")
+				logger.Info("info")
+			},
+			expected: "level=INFO msg=grpc\nlevel=INFO msg=info\n",
+		},
+		{
+			description: "package level",
+			levels: []log.Level{
+				{Name: "github.com/nil-go/nilgo/log_test", Level: slog.LevelDebug},
+				{Name: "github.com/nil-go/nilgo/log", Level: slog.LevelError},
+			},
+			log: func(logger *slog.Logger) {
+				logger.Debug("debug")
+			},
+			expected: "level=DEBUG msg=debug\n",
+		},
+		{
+			description: "longest match",
+			levels: []log.Level{
+				{Level: slog.LevelError},
+				{Name: "http", Level: slog.LevelWarn},
+				{Name: "http.request", Level: slog.LevelDebug},
+			},
+			log: func(logger *slog.Logger) {
+				logger.WithGroup("http").Info("http")
+				logger.WithGroup("http").WithGroup("request").Debug("request")
+				logger.Warn("warn")
+			},
+			expected: "level=DEBUG msg=request\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			control := log.NewControl()
+			for _, level := range testcase.levels {
+				control.Set(level.Name, level.Level, 0)
+			}
+			buf := new(bytes.Buffer)
+			testcase.log(slog.New(log.NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+					if len(groups) == 0 && attr.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+
+					return attr
+				},
+			}), control)))
+			assert.Equal(t, testcase.expected, buf.String())
+		})
+	}
+}
+
+func TestControl(t *testing.T) {
+	t.Parallel()
+
+	control := log.NewControl()
+	_, ok := control.Level("app")
+	assert.Equal(t, false, ok)
+
+	control.Set("app", slog.LevelDebug, 0)
+	control.Set("app.db", slog.LevelWarn, 10*time.Millisecond)
+	level, ok := control.Level("app.db.query")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, slog.LevelWarn, level)
+	level, _ = control.Level("app/user")
+	assert.Equal(t, slog.LevelDebug, level)
+	_, ok = control.Level("application")
+	assert.Equal(t, false, ok)
+
+	time.Sleep(20 * time.Millisecond)
+	level, _ = control.Level("app.db.query")
+	assert.Equal(t, slog.LevelDebug, level)
+	assert.Equal(t, []log.Level{{Name: "app", Level: slog.LevelDebug}}, control.Levels())
+
+	control.Reset("app")
+	_, ok = control.Level("app")
+	assert.Equal(t, false, ok)
+	assert.Equal(t, []log.Level{}, control.Levels())
+}
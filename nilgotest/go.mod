@@ -0,0 +1,41 @@
+module github.com/nil-go/nilgo/nilgotest
+
+go 1.22
+
+require (
+	github.com/nil-go/nilgo v0.3.0
+	github.com/nil-go/nilgo/grpc v0.3.0
+	github.com/nil-go/nilgo/http v0.3.0
+	go.uber.org/goleak v1.3.0
+	google.golang.org/grpc v1.69.2
+)
+
+require (
+	github.com/fsnotify/fsnotify v1.8.0 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/nil-go/konf v1.4.0 // indirect
+	github.com/nil-go/konf/provider/file v1.4.0 // indirect
+	github.com/nil-go/nilgo/config v0.3.0 // indirect
+	github.com/nil-go/sloth v0.3.0 // indirect
+	go.opentelemetry.io/otel v1.32.0 // indirect
+	go.opentelemetry.io/otel/metric v1.32.0 // indirect
+	go.opentelemetry.io/otel/trace v1.32.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241118233622-e639e219e697 // indirect
+	google.golang.org/protobuf v1.36.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The modules in this repository are developed together and tagged with the same version at the same commit,
+// root and config first (see .github/workflows/release.yml). The replacements only apply to the development here,
+// since Go ignores them in dependencies.
+replace (
+	github.com/nil-go/nilgo => ../
+	github.com/nil-go/nilgo/config => ../config
+	github.com/nil-go/nilgo/grpc => ../grpc
+	github.com/nil-go/nilgo/http => ../http
+)
@@ -0,0 +1,212 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package nilgotest provides helpers to run nilgo.Runner in tests.
+package nilgotest
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/nil-go/nilgo"
+	ngrpc "github.com/nil-go/nilgo/grpc"
+	nhttp "github.com/nil-go/nilgo/http"
+	"github.com/nil-go/nilgo/http/client"
+	"github.com/nil-go/nilgo/socket"
+)
+
+// App is the running Runner started by [Start].
+type App struct {
+	runner nilgo.Runner
+
+	httpEndpoint string
+	httpClient   *http.Client
+	grpcAddress  string
+	grpcConn     *grpc.ClientConn
+
+	logs *logBuffer
+}
+
+// Start executes the Runner created by nilgo.New with the given options in background,
+// and blocks until the Runner is running and all servers are accepting connections.
+// It captures logs from slog.Default during the execution.
+//
+// On cleanup of the test, it shuts down the Runner, and fails the test if the Runner
+// does not exit gracefully within the timeout, or there are leaked goroutines.
+// It changes slog.Default, so tests using Start should not run in parallel.
+func Start(t testing.TB, runner nilgo.Runner, opts ...Option) *App { //nolint:funlen,thelper
+	t.Helper()
+
+	// The zero Runner has no lifecycle to report it's running, so it would wait until the timeout.
+	if reflect.ValueOf(runner).IsZero() {
+		t.Fatal("runner is not created by nilgo.New")
+	}
+
+	option := &options{}
+	for _, opt := range opts {
+		opt(option)
+	}
+	if option.timeout == 0 {
+		option.timeout = 10 * time.Second //nolint:mnd
+	}
+	var leakOpts []goleak.Option
+	if !option.skipLeakCheck {
+		leakOpts = append(leakOpts, goleak.IgnoreCurrent())
+	}
+
+	app := &App{runner: runner, logs: &logBuffer{}}
+	logger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(app.logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	runs := option.runs
+	var httpBound, grpcBound socket.Bound
+	if option.http != nil {
+		runs = append(runs, nhttp.Run(option.http.server,
+			append(option.http.opts, nhttp.WithAddress("localhost:0"), nhttp.WithBound(&httpBound))...,
+		))
+	}
+	if option.grpc != nil {
+		runs = append(runs, ngrpc.Run(option.grpc.server,
+			append(option.grpc.opts, ngrpc.WithAddress("localhost:0"), ngrpc.WithBound(&grpcBound))...,
+		))
+	}
+
+	running := make(chan struct{})
+	unsubscribe := runner.Subscribe(func(event nilgo.Event) {
+		if event.Kind == nilgo.EventStateChanged && event.State == nilgo.StateRunning {
+			close(running)
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- runner.Run(ctx, runs...)
+	}()
+
+	t.Cleanup(func() {
+		defer slog.SetDefault(logger)
+
+		if app.httpClient != nil {
+			app.httpClient.CloseIdleConnections()
+		}
+		if app.grpcConn != nil {
+			if err := app.grpcConn.Close(); err != nil {
+				t.Errorf("close gRPC client connection: %v", err)
+			}
+		}
+
+		cancel()
+		timer := time.NewTimer(option.timeout)
+		defer timer.Stop()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("runner exits with error: %v", err)
+			}
+		case <-timer.C:
+			t.Errorf("runner does not exit within %s", option.timeout)
+
+			return
+		}
+
+		if !option.skipLeakCheck {
+			if err := goleak.Find(leakOpts...); err != nil {
+				t.Errorf("leaked goroutines: %v", err)
+			}
+		}
+	})
+
+	readyCtx, readyCancel := context.WithTimeout(ctx, option.timeout)
+	defer readyCancel()
+	select {
+	case <-running:
+		unsubscribe()
+	case err := <-done:
+		done <- err // For cleanup.
+		t.Fatalf("runner exits before running: %v", err)
+	case <-readyCtx.Done():
+		t.Fatalf("runner is not running within %s", option.timeout)
+	}
+
+	if option.http != nil {
+		addrs, err := httpBound.Addrs(readyCtx)
+		if err != nil {
+			t.Fatalf("wait for HTTP server: %v", err)
+		}
+		app.httpEndpoint = "http://" + addrs[0].String()
+		app.httpClient = client.New()
+	}
+	if option.grpc != nil {
+		addrs, err := grpcBound.Addrs(readyCtx)
+		if err != nil {
+			t.Fatalf("wait for gRPC server: %v", err)
+		}
+		app.grpcAddress = addrs[0].String()
+		conn, err := grpc.NewClient(app.grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("create gRPC client connection: %v", err)
+		}
+		app.grpcConn = conn
+	}
+
+	return app
+}
+
+// Runner returns the running Runner.
+func (a *App) Runner() nilgo.Runner {
+	return a.runner
+}
+
+// HTTPEndpoint returns the endpoint of the HTTP server provided by [WithHTTP], e.g. `http://127.0.0.1:12345`.
+func (a *App) HTTPEndpoint() string {
+	return a.httpEndpoint
+}
+
+// HTTPClient returns the HTTP client for the HTTP server provided by [WithHTTP].
+func (a *App) HTTPClient() *http.Client {
+	return a.httpClient
+}
+
+// GRPCAddress returns the address of the gRPC server provided by [WithGRPC], e.g. `127.0.0.1:12345`.
+func (a *App) GRPCAddress() string {
+	return a.grpcAddress
+}
+
+// GRPCConn returns the client connection to the gRPC server provided by [WithGRPC].
+func (a *App) GRPCConn() *grpc.ClientConn {
+	return a.grpcConn
+}
+
+// Logs returns the logs captured since the Runner starts in text format.
+func (a *App) Logs() string {
+	return a.logs.String()
+}
+
+type logBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.Write(p) //nolint:wrapcheck
+}
+
+func (b *logBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.String()
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgotest_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/nil-go/nilgo"
+	ngrpc "github.com/nil-go/nilgo/grpc"
+	"github.com/nil-go/nilgo/health"
+	nhttp "github.com/nil-go/nilgo/http"
+	"github.com/nil-go/nilgo/nilgotest"
+	"github.com/nil-go/nilgo/nilgotest/internal/assert"
+)
+
+func TestStart(t *testing.T) {
+	app := nilgotest.Start(t, nilgo.New(),
+		nilgotest.WithHTTP(nil, nhttp.WithHealth(health.New())),
+		nilgotest.WithGRPC(ngrpc.NewServer(), ngrpc.WithHealth(health.New())),
+		nilgotest.WithRun(func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return nil
+		}),
+	)
+	assert.Equal(t, nilgo.StateRunning, app.Runner().State())
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, app.HTTPEndpoint()+"/healthz", nil)
+	assert.NoError(t, err)
+	resp, err := app.HTTPClient().Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	hcResp, err := grpc_health_v1.NewHealthClient(app.GRPCConn()).
+		Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, hcResp.GetStatus())
+
+	logs := app.Logs()
+	assert.Equal(t, true, strings.Contains(logs, "HTTP Server listens on "+strings.TrimPrefix(app.HTTPEndpoint(), "http://")))
+	assert.Equal(t, true, strings.Contains(logs, "gRPC Server listens on "+app.GRPCAddress()))
+}
+
+func TestStart_zeroRunner(t *testing.T) {
+	fatalT := &fatalTB{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		nilgotest.Start(fatalT, nilgo.Runner{})
+	}()
+	<-done
+	assert.Equal(t, "runner is not created by nilgo.New", fatalT.message)
+}
+
+type fatalTB struct {
+	testing.TB
+
+	message string
+}
+
+func (f *fatalTB) Fatal(args ...any) {
+	f.message = fmt.Sprint(args...)
+	runtime.Goexit()
+}
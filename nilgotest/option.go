@@ -0,0 +1,75 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgotest
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+
+	ngrpc "github.com/nil-go/nilgo/grpc"
+	nhttp "github.com/nil-go/nilgo/http"
+)
+
+// WithRun provides runs to execute along with the servers in Runner.Run.
+func WithRun(runs ...func(context.Context) error) Option {
+	return func(options *options) {
+		options.runs = append(options.runs, runs...)
+	}
+}
+
+// WithHTTP runs the HTTP server with the given options, which listens on an ephemeral port of localhost.
+// The address and client of the server are accessible with App.HTTPEndpoint and App.HTTPClient.
+func WithHTTP(server *http.Server, opts ...nhttp.Option) Option {
+	return func(options *options) {
+		options.http = &httpServer{server: server, opts: opts}
+	}
+}
+
+// WithGRPC runs the gRPC server with the given options, which listens on an ephemeral port of localhost.
+// The address and client connection of the server are accessible with App.GRPCAddress and App.GRPCConn.
+func WithGRPC(server *grpc.Server, opts ...ngrpc.Option) Option {
+	return func(options *options) {
+		options.grpc = &grpcServer{server: server, opts: opts}
+	}
+}
+
+// WithTimeout provides the timeout for the Runner to be ready on start, and to exit gracefully on cleanup.
+//
+// By default, it's 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *options) {
+		options.timeout = timeout
+	}
+}
+
+// WithoutLeakCheck disables checking leaked goroutines on cleanup,
+// which is necessary if the test runs in parallel with other tests.
+func WithoutLeakCheck() Option {
+	return func(options *options) {
+		options.skipLeakCheck = true
+	}
+}
+
+type (
+	// Option configures the App with specific options.
+	Option  func(*options)
+	options struct {
+		runs          []func(context.Context) error
+		http          *httpServer
+		grpc          *grpcServer
+		timeout       time.Duration
+		skipLeakCheck bool
+	}
+	httpServer struct {
+		server *http.Server
+		opts   []nhttp.Option
+	}
+	grpcServer struct {
+		server *grpc.Server
+		opts   []ngrpc.Option
+	}
+)
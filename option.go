@@ -6,11 +6,16 @@ package nilgo
 import (
 	"context"
 	"log/slog"
+	"os"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nil-go/nilgo/container"
+	"github.com/nil-go/nilgo/log"
 )
 
 // WithPreRun provides runs to execute before the main runs provided in Runner.Run.
@@ -50,10 +55,213 @@ func WithStopGate(gates ...func(context.Context) error) Option {
 	}
 }
 
+// WithSingleInstance guards the Runner as the single instance on the host with an exclusive lock
+// on the pid file at the given path, which also records the pid of the process.
+//
+// It acquires the lock as a pre run, and blocks the main runs until the lock is acquired.
+// If another instance holds the lock, it fails with [ErrLocked] immediately if the wait is not positive,
+// otherwise it waits for the lock to be released at most the wait duration.
+// It removes the pid file and releases the lock as a post run, even if the shutdown is triggered by a run failure.
+// The Runner acquires the lock for each execution, so it can run again,
+// while its concurrent executions are locked out like other instances.
+func WithSingleInstance(pidFile string, wait time.Duration) Option {
+	return func(options *options) {
+		instance := &instance{path: pidFile, wait: wait}
+		WithPreRun(instance.lock)(options)
+		WithStartGate(instance.ready)(options)
+		WithPostRun(instance.unlock)(options)
+	}
+}
+
+// WithShutdownTimeout provides the timeout for each phase of shutdown, which includes
+// waiting for stop gates, draining main runs and waiting for post runs.
+// Once the timeout exceeds, the Runner logs the blocked runs with goroutine stacks,
+// moves to the next phase, and eventually returns [ErrShutdownTimeout].
+//
+// By default, it waits until all runs return.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(options *options) {
+		options.shutdownTimeout = timeout
+	}
+}
+
+// WithShutdownSignals provides the OS signals which start shutdown of the Runner.
+// No OS signal starts shutdown if it's called without signals.
+//
+// By default, it's syscall.SIGINT and syscall.SIGTERM.
+func WithShutdownSignals(signals ...os.Signal) Option {
+	return func(options *options) {
+		options.shutdownSignals = append([]os.Signal{}, signals...)
+	}
+}
+
+// WithSignalHandler provides the handler to execute when the Runner receives any of the given OS signals,
+// without stopping the Runner. The handlers for the same signal execute in order,
+// and the error of the handler is logged.
+//
+// e.g. [Dump] for syscall.SIGUSR1. The shutdown signals can not be handled.
+//
+// By default, other OS signals keep their default behavior, e.g. syscall.SIGHUP terminates the process.
+func WithSignalHandler(handler func(context.Context) error, signals ...os.Signal) Option {
+	return func(options *options) {
+		options.signalHandlers = append(options.signalHandlers, signalHandler{signals: signals, handler: handler})
+	}
+}
+
+// WithUpgrade enables zero-downtime upgrade when the Runner receives any of the given OS signals,
+// e.g. syscall.SIGUSR2.
+//
+// On upgrade, the Runner re-executes the current binary with the same arguments and environment,
+// which inherits all listeners created by github.com/nil-go/nilgo/socket.Listen (e.g. HTTP and gRPC servers),
+// and starts shutdown once the new process is running and listens on all inherited listeners.
+// The Runner keeps running if the new process fails to reach that within 1 minute.
+// So the new process must listen on all addresses of the current process, e.g. the servers start successfully.
+func WithUpgrade(signals ...os.Signal) Option {
+	return func(options *options) {
+		options.upgradeSignals = append(options.upgradeSignals, signals...)
+	}
+}
+
+// WithReloadSignals reloads the Runner when it receives any of the given OS signals, e.g. syscall.SIGHUP.
+// See Runner.Reload for details.
+func WithReloadSignals(signals ...os.Signal) Option {
+	return func(options *options) {
+		options.reloadSignals = append(options.reloadSignals, signals...)
+	}
+}
+
+// WithReloadHook provides hooks to execute when the Runner reloads, e.g. re-read configuration.
+// See Runner.Reload for details.
+func WithReloadHook(hooks ...func(context.Context) error) Option {
+	return func(options *options) {
+		options.reloadHooks = append(options.reloadHooks, hooks...)
+	}
+}
+
+// Named provides a main run with the given name, which executes along with the runs provided in Runner.Run.
+//
+// Other named runs can declare dependency on it with [After]. The named runs start in topological order
+// of their dependencies, and stop in reverse order, which means the context of a named run is canceled
+// only after all runs depending on it have returned.
+func Named(name string, run func(context.Context) error, opts ...RunOption) Option {
+	return func(options *options) {
+		named := namedRun{
+			name: name,
+			run:  run,
+			supervision: supervision{
+				minBackoff:    100 * time.Millisecond, //nolint:mnd
+				maxBackoff:    10 * time.Second,       //nolint:mnd
+				maxRestarts:   5,                      //nolint:mnd
+				restartWindow: time.Minute,
+			},
+		}
+		for _, opt := range opts {
+			opt(&named)
+		}
+		if len(named.restartTriggers) > 0 {
+			named.run = named.restartOnTrigger
+		}
+		if named.supervision.policy != RestartNever {
+			named.run = named.supervise
+		}
+		options.runs = append(options.runs, named)
+	}
+}
+
+// After declares the named run depends on the named runs with the given names,
+// so it starts after they are ready, and stops before them.
+func After(names ...string) RunOption {
+	return func(run *namedRun) {
+		run.after = append(run.after, names...)
+	}
+}
+
+// Ready provides a gate to determine when the named run is ready for its dependents.
+// The gate executes along with the run, and the run is ready once the gate returns without error.
+//
+// If the run returns without error before the gate passes, e.g. one-shot migration, it's also ready.
+//
+// By default, the named run is ready as soon as it starts.
+func Ready(gate func(context.Context) error) RunOption {
+	return func(run *namedRun) {
+		run.ready = gate
+	}
+}
+
+// Restart provides the restart policy for the named run, so a non-critical run can crash and restart
+// without shutting down other runs. Each restart is logged and counted by metric `nilgo.run.restarts`.
+//
+// By default, the named run never restarts.
+func Restart(policy RestartPolicy) RunOption {
+	return func(run *namedRun) {
+		run.supervision.policy = policy
+	}
+}
+
+// RestartBackoff provides the exponential backoff between restarts of the named run,
+// which starts from minBackoff and doubles on each restart until maxBackoff.
+//
+// By default, it backoffs from 100 milliseconds to 10 seconds.
+func RestartBackoff(minBackoff, maxBackoff time.Duration) RunOption {
+	return func(run *namedRun) {
+		run.supervision.minBackoff = minBackoff
+		run.supervision.maxBackoff = maxBackoff
+	}
+}
+
+// RestartLimit provides the maximum restarts of the named run within the given window.
+// Once the restarts are exhausted, the error of the run shuts down the Runner.
+//
+// By default, it restarts at most 5 times within 1 minute.
+func RestartLimit(maxRestarts int, window time.Duration) RunOption {
+	return func(run *namedRun) {
+		run.supervision.maxRestarts = maxRestarts
+		run.supervision.restartWindow = window
+	}
+}
+
+// RestartOn restarts the named run gracefully once any of the given triggers fires,
+// for the run which can not reconfigure in place, e.g. config change
+// (see github.com/nil-go/nilgo/config.RestartOnChange).
+// The trigger registers the restart function while the run is running, and returns the function to unregister it.
+//
+// Unlike [Restart], the restart is not counted in the restart limit.
+func RestartOn(triggers ...func(restart func()) func()) RunOption {
+	return func(run *namedRun) {
+		run.restartTriggers = append(run.restartTriggers, triggers...)
+	}
+}
+
+// WithOptions combines the given options as one option,
+// e.g. for other packages to provide the option which composes multiple options.
+func WithOptions(opts ...Option) Option {
+	return func(options *options) {
+		for _, opt := range opts {
+			opt(options)
+		}
+	}
+}
+
+// WithContainerLimits tunes GOMAXPROCS and GOMEMLIMIT for the CPU quota and memory limit of the container
+// before the main runs start, while it keeps the values provided by the environment variables.
+// See [container.Tune] for details. The failure of reading the limits is logged without stopping the Runner.
+func WithContainerLimits(opts ...container.Option) Option {
+	return func(options *options) {
+		WithStartGate(func(ctx context.Context) error {
+			if _, err := container.Tune(opts...); err != nil {
+				slog.LogAttrs(ctx, slog.LevelWarn, "Fail to tune runtime for container limits.", slog.Any("error", err))
+			}
+
+			return nil
+		})(options)
+	}
+}
+
 // WithLogger provides a slog.Logger to handle logs.
+// Its handler is wrapped with the default log.Control, so the log levels can be changed at runtime.
 func WithLogger(logger *slog.Logger) Option {
 	return func(*options) {
-		slog.SetDefault(logger)
+		slog.SetDefault(slog.New(log.NewHandler(logger.Handler(), log.Default())))
 		slog.Info("Logger has been initialized.")
 	}
 }
@@ -104,4 +312,7 @@ type (
 	// Option configures the Runner with specific options.
 	Option  func(*options)
 	options Runner
+
+	// RunOption configures the named run with specific options.
+	RunOption func(*namedRun)
 )
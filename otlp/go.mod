@@ -3,6 +3,8 @@ module github.com/nil-go/nilgo/otlp
 go 1.22
 
 require (
+	github.com/nil-go/konf v1.4.0
+	github.com/nil-go/nilgo/config v0.3.0
 	github.com/stretchr/testify v1.10.0
 	go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc v1.32.0
 	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.32.0
@@ -13,10 +15,13 @@ require (
 require (
 	github.com/cenkalti/backoff/v4 v4.3.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/fsnotify/fsnotify v1.8.0 // indirect
 	github.com/go-logr/logr v1.4.2 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.23.0 // indirect
+	github.com/nil-go/konf/provider/file v1.4.0 // indirect
+	github.com/nil-go/nilgo v0.3.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.opentelemetry.io/otel v1.32.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.32.0 // indirect
@@ -32,3 +37,11 @@ require (
 	google.golang.org/protobuf v1.35.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// The modules in this repository are developed together and tagged with the same version at the same commit,
+// root and config first (see .github/workflows/release.yml). The replacements only apply to the development here,
+// since Go ignores them in dependencies.
+replace (
+	github.com/nil-go/nilgo => ../
+	github.com/nil-go/nilgo/config => ../config
+)
@@ -16,6 +16,10 @@ import (
 )
 
 // TraceProvider creates a new trace provider with OTLP exporter.
+//
+// It samples the root spans with the ratio in config `trace.samplingRatio` from the global konf.Config,
+// and the change of the ratio is applied in place (see github.com/nil-go/nilgo/config.OnChange).
+// The child spans follow the sampling decision of the parent.
 func TraceProvider(opts ...otlptracegrpc.Option) (*trace.TracerProvider, error) {
 	opts = append([]otlptracegrpc.Option{otlptracegrpc.WithInsecure()}, opts...)
 	exporter, err := otlptracegrpc.New(context.Background(), opts...)
@@ -26,7 +30,7 @@ func TraceProvider(opts ...otlptracegrpc.Option) (*trace.TracerProvider, error)
 	return trace.NewTracerProvider(
 		trace.WithBatcher(exporter),
 		trace.WithResource(resource.Default()),
-		trace.WithSampler(trace.ParentBased(trace.NeverSample())),
+		trace.WithSampler(trace.ParentBased(sharedRatioSampler())),
 	), nil
 }
 
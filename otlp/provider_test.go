@@ -4,11 +4,14 @@
 package otlp_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/nil-go/konf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/nil-go/nilgo/config"
 	"github.com/nil-go/nilgo/otlp"
 )
 
@@ -27,3 +30,60 @@ func TestMeterProvider(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, provider)
 }
+
+//nolint:paralleltest // It changes the default config.
+func TestTraceProvider_samplingRatio(t *testing.T) {
+	provider, err := otlp.TraceProvider()
+	require.NoError(t, err)
+	tracer := provider.Tracer("test")
+	sampled := func() bool {
+		_, span := tracer.Start(context.Background(), "span")
+		defer span.End()
+
+		return span.SpanContext().IsSampled()
+	}
+	assert.False(t, sampled())
+
+	// The ratio is applied once the default config is set, e.g. by config.Bootstrap.
+	loader := &watchLoader{
+		values:   map[string]any{"trace": map[string]any{"samplingRatio": 1}},
+		onChange: make(chan func(map[string]any), 1),
+	}
+	cfg := konf.New()
+	require.NoError(t, cfg.Load(loader))
+	config.SetDefault(cfg)
+	defer config.SetDefault(konf.New())
+	assert.True(t, sampled())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, cfg.Watch(ctx))
+	}()
+	// The sampler subscribes the ratio ahead, so it applies the change before this subscription.
+	applied := make(chan float64, 1)
+	defer config.OnChange("trace.samplingRatio", func(ratio float64) error {
+		applied <- ratio
+
+		return nil
+	})()
+	(<-loader.onChange)(map[string]any{"trace": map[string]any{"samplingRatio": 0}})
+	assert.InDelta(t, 0, <-applied, 0)
+	assert.False(t, sampled())
+}
+
+type watchLoader struct {
+	values   map[string]any
+	onChange chan func(map[string]any)
+}
+
+func (w *watchLoader) Load() (map[string]any, error) {
+	return w.values, nil
+}
+
+func (w *watchLoader) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	w.onChange <- onChange
+	<-ctx.Done()
+
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package otlp
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nil-go/konf"
+	"go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/nil-go/nilgo/config"
+)
+
+// ratioSampler samples the root spans with the ratio in config `trace.samplingRatio`,
+// and applies the change of the ratio in place. It never samples if the ratio is not configured.
+type ratioSampler struct {
+	sampler atomic.Pointer[trace.Sampler]
+}
+
+// sharedRatioSampler returns the ratioSampler shared by all trace providers,
+// so the change of the ratio is subscribed only once in the process.
+//
+//nolint:gochecknoglobals
+var sharedRatioSampler = sync.OnceValue(newRatioSampler)
+
+func newRatioSampler() *ratioSampler {
+	sampler := &ratioSampler{}
+	sampler.set(konf.Get[float64](samplingRatioPath))
+	config.OnChange(samplingRatioPath, func(ratio float64) error {
+		if ratio < 0 || ratio > 1 {
+			return fmt.Errorf("invalid sampling ratio %v: it must be in [0, 1]", ratio) //nolint:err113
+		}
+		sampler.set(ratio)
+
+		return nil
+	})
+
+	return sampler
+}
+
+func (r *ratioSampler) set(ratio float64) {
+	sampler := trace.TraceIDRatioBased(ratio)
+	r.sampler.Store(&sampler)
+}
+
+func (r *ratioSampler) ShouldSample(parameters trace.SamplingParameters) trace.SamplingResult {
+	return (*r.sampler.Load()).ShouldSample(parameters)
+}
+
+func (r *ratioSampler) Description() string {
+	return (*r.sampler.Load()).Description()
+}
+
+const samplingRatioPath = "trace.samplingRatio"
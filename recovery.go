@@ -0,0 +1,16 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/nil-go/nilgo/internal/recovery"
+)
+
+// recovered executes the run, and recovers the panic from the run as an error which wraps [ErrPanic].
+func recovered(ctx context.Context, name string, run func(context.Context) error) error {
+	return recovery.Recover(ctx, run, slog.String("run", name))
+}
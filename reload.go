@@ -0,0 +1,107 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// OnReload registers the hook to execute when the Runner reloads,
+// and returns the function to unregister it.
+//
+// It's for packages to plug into reloading during the execution, e.g. reopen log files
+// or reload TLS certificates. The hooks execute in parallel along with the hooks provided by [WithReloadHook].
+func (r Runner) OnReload(hook func(context.Context) error) func() {
+	if r.reloader == nil || hook == nil {
+		return func() {}
+	}
+
+	return r.reloader.register(hook)
+}
+
+// Reload executes all reload hooks in parallel, and returns the errors of failed hooks.
+//
+// It's triggered by the OS signals provided by [WithReloadSignals].
+// A failed reload does not stop the Runner. Reloads are executed one at a time.
+func (r Runner) Reload(ctx context.Context) error {
+	if r.reloader == nil {
+		r.reloader = &reloader{}
+	}
+	if r.lifecycle == nil {
+		r.lifecycle = &lifecycle{}
+	}
+
+	r.reloader.running.Lock()
+	defer r.reloader.running.Unlock()
+
+	slog.LogAttrs(ctx, slog.LevelInfo, "Starting reload...")
+	r.lifecycle.emit(Event{Kind: EventReloadStarted})
+
+	hooks := append(r.reloader.registered(), r.reloadHooks...)
+	errs := make([]error, len(hooks))
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(len(hooks))
+	for i, hook := range unnamed(hooks) {
+		go func() {
+			defer waitGroup.Done()
+
+			if err := recovered(ctx, hook.name, hook.run); err != nil {
+				errs[i] = fmt.Errorf("reload hook %s: %w", hook.name, err)
+			}
+		}()
+	}
+	waitGroup.Wait()
+	err := errors.Join(errs...)
+
+	r.lifecycle.emit(Event{Kind: EventReloadFinished, Err: err})
+	if err != nil {
+		slog.LogAttrs(ctx, slog.LevelError, "Reload failed.", slog.Any("error", err))
+
+		return err
+	}
+	slog.LogAttrs(ctx, slog.LevelInfo, "Reload completed.")
+
+	return nil
+}
+
+type reloader struct {
+	mutex sync.RWMutex
+	hooks map[*func(context.Context) error]struct{}
+
+	running sync.Mutex
+}
+
+func (r *reloader) register(hook func(context.Context) error) func() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.hooks == nil {
+		r.hooks = make(map[*func(context.Context) error]struct{})
+	}
+	key := &hook
+	r.hooks[key] = struct{}{}
+
+	return func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		delete(r.hooks, key)
+	}
+}
+
+func (r *reloader) registered() []func(context.Context) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	hooks := make([]func(context.Context) error, 0, len(r.hooks))
+	for hook := range r.hooks {
+		hooks = append(hooks, *hook)
+	}
+
+	return hooks
+}
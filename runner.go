@@ -7,10 +7,13 @@ package nilgo
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"os"
 	"os/signal"
 	"slices"
 	"sync"
-	"syscall"
+	"time"
 )
 
 // Runner is a pre-configured runtime for executing runs in parallel.
@@ -21,15 +24,37 @@ type Runner struct {
 	postRuns   []func(context.Context) error
 	startGates []func(context.Context) error
 	stopGates  []func(context.Context) error
+	runs       []namedRun
+
+	shutdownSignals []os.Signal
+	upgradeSignals  []os.Signal
+	reloadSignals   []os.Signal
+	signalHandlers  []signalHandler
+	reloadHooks     []func(context.Context) error
+
+	shutdownTimeout time.Duration
+	lifecycle       *lifecycle
+	reloader        *reloader
 }
 
 // New creates a new Runner with the given Option(s).
+//
+// It panics if the named runs provided by [Named] have duplicate names,
+// unknown dependencies or dependency cycles.
 func New(opts ...Option) Runner {
 	option := &options{}
 	for _, opt := range opts {
 		opt(option)
 	}
 
+	runs, err := sortRuns(option.runs)
+	if err != nil {
+		panic("nilgo: " + err.Error())
+	}
+	option.runs = runs
+	option.lifecycle = &lifecycle{}
+	option.reloader = &reloader{}
+
 	return Runner(*option)
 }
 
@@ -38,10 +63,20 @@ func New(opts ...Option) Runner {
 //
 // The run running in parallel without any explicit order,
 // which means it should not have temporal dependencies between each other.
+// The named runs provided by [Named] run along with the given runs,
+// but start in topological order of their dependencies and stop in reverse order.
 //
 // The execution can be interrupted if any run returns non-nil error,
-// or it receives an OS signal syscall.SIGINT or syscall.SIGTERM.
-// It waits all run return unless it's forcefully terminated by OS.
+// or it receives an OS signal syscall.SIGINT or syscall.SIGTERM, which can be changed by [WithShutdownSignals].
+// Other OS signals are dispatched to the handlers provided by [WithSignalHandler] without interruption.
+// It also reloads on the signals provided by [WithReloadSignals], e.g. syscall.SIGHUP.
+// It also re-executes the binary for zero-downtime upgrade on the signals provided by [WithUpgrade].
+// It returns [*Error] which records all failed runs if any run returns non-nil error.
+// The panic in any run is recovered and logged, and fails the run with an error which wraps [ErrPanic].
+// It waits all run return unless it's forcefully terminated by OS,
+// or it receives another OS signal during the shutdown started by an OS signal,
+// which returns [ErrShutdownForced] immediately.
+// The waiting of stop gates, main runs and post runs during shutdown can be bounded by [WithShutdownTimeout].
 //
 // The execution flow is as follows:
 // 1. Starts all pre runs and start gates in parallel.
@@ -51,79 +86,200 @@ func New(opts ...Option) Runner {
 // 5. Waits for all stop gates complete.
 // 6. Stop all main runs.
 // 7. Waits for all post runs complete.
+//
+// The current step is reported by Runner.State, and the lifecycle events can be received
+// with Runner.Subscribe. The Runner is also accessible in runs with [FromContext].
 func (r Runner) Run(ctx context.Context, runs ...func(context.Context) error) error { //nolint:funlen
-	preRuns := make([]func(context.Context) error, 0, len(r.preRuns))
-	startGates := slices.Clone(r.startGates)
-	if len(r.preRuns) > 0 {
+	if r.lifecycle == nil {
+		r.lifecycle = &lifecycle{}
+	}
+	if r.reloader == nil {
+		r.reloader = &reloader{}
+	}
+	ctx = context.WithValue(ctx, runnerKey{}, r)
+	r.lifecycle.transit(ctx, StateStarting)
+	defer r.lifecycle.transit(ctx, StateStopped)
+
+	preRuns := unnamed(r.preRuns)
+	startGates := unnamed(r.startGates)
+	if len(preRuns) > 0 {
 		// Append wait group to wait for all pre runs to start.
 		var waitGroup sync.WaitGroup
-		waitGroup.Add(len(r.preRuns))
-		for _, run := range r.preRuns {
-			preRuns = append(preRuns,
-				func(ctx context.Context) error {
-					waitGroup.Done()
-
-					return run(ctx)
-				},
-			)
+		waitGroup.Add(len(preRuns))
+		for i, run := range preRuns {
+			preRuns[i].run = func(ctx context.Context) error {
+				waitGroup.Done()
+
+				return run.run(ctx)
+			}
 		}
 		// Add gate to wait for all pre runs to start.
-		startGates = append(startGates,
-			func(context.Context) error {
+		startGates = append(startGates, namedRun{
+			name: "pre runs",
+			run: func(context.Context) error {
 				waitGroup.Wait()
 
 				return nil
 			},
-		)
+		})
 	}
 
-	// Context can be terminated by either OS signals or cancellation on ctx.
-	signalCtx, signalCancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	// Context can be terminated by either OS signals, cancellation on ctx or any run failure.
+	signals := make(chan os.Signal, 1)
+	if shutdownSignals := r.shutdownSignalSet(); len(shutdownSignals) > 0 {
+		signal.Notify(signals, shutdownSignals...)
+	}
+	defer signal.Stop(signals)
+	signalCtx, signalCancel := context.WithCancel(ctx)
 	defer signalCancel()
+	exec := &execution{Runner: r, stopping: signalCtx.Done(), shutdown: signalCancel}
 
 	// Root context which is used for pre/post runs.
 	// It does not propagate the cancellation from ctx.
 	// It depends on signalCtx for cancellation.
-	rootCtx, rootCancel := context.WithCancelCause(context.WithoutCancel(ctx))
-	defer rootCancel(nil)
+	// It carries the execution, so the runs and gates can share the state of this execution.
+	rootCtx, rootCancel := context.WithCancel(context.WithValue(context.WithoutCancel(ctx), executionKey{}, exec))
+	defer rootCancel()
 	// Context is used for main runs with start/stop gates.
 	runCtx, runCancel := context.WithCancel(rootCtx)
 	defer runCancel()
 
-	return parallel(rootCtx,
-		append(preRuns,
-			func(context.Context) error {
-				defer runCancel() // Notify all main runs to stop.
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(4) //nolint:mnd
+	stopped := make(chan struct{})
+	handleSignals := r.handleSignals(rootCtx, func(ctx context.Context) error {
+		if err := r.upgrade(ctx); err != nil {
+			return err
+		}
+		slog.LogAttrs(ctx, slog.LevelInfo, "Upgrade completed, starting shutdown...")
+		signalCancel()
 
-				<-signalCtx.Done()
+		return nil
+	})
+	go func() {
+		defer waitGroup.Done()
 
-				// Wait for all stop gates to open.
-				return parallel(runCtx, r.stopGates...)
-			},
-			func(context.Context) (err error) { //nolint:nonamedreturns
-				defer func() {
-					signalCancel() // Stop listening to OS signals.
-
-					// Wait for all post runs to finish.
-					e := parallel(rootCtx, r.postRuns...)
-					if err == nil {
-						err = e
-					}
-					rootCancel(err) // Notify all pre runs to stop.
-				}()
-
-				// Wait for all start gates to open.
-				if err = parallel(runCtx, startGates...); err != nil {
-					return err
-				}
-
-				return parallel(runCtx, runs...)
-			},
-		)...,
-	)
+		handleSignals()
+	}()
+	go func() {
+		defer waitGroup.Done()
+
+		exec.bounded(rootCtx, rootCtx.Done(), PhasePreRun, preRuns)
+	}()
+	go func() {
+		defer waitGroup.Done()
+		defer runCancel() // Notify all main runs to stop.
+		defer close(stopped)
+
+		<-signalCtx.Done()
+
+		// Wait for all stop gates to open.
+		r.lifecycle.transit(rootCtx, StateStopping)
+		stopCtx, stopCancel := r.shutdownContext(runCtx)
+		defer stopCancel()
+		exec.bounded(stopCtx, nil, PhaseStopGate, unnamed(r.stopGates))
+		r.lifecycle.transit(rootCtx, StateDraining)
+	}()
+	go func() {
+		waitUpgraded := func() {}
+		defer waitGroup.Done()
+		defer func() {
+			signalCancel() // Start shutdown if main runs complete by themselves.
+			<-stopped      // Wait for all stop gates to open.
+			waitUpgraded()
+
+			// Wait for all post runs to finish.
+			r.lifecycle.transit(rootCtx, StateFinishing)
+			postCtx, postCancel := r.shutdownContext(rootCtx)
+			defer postCancel()
+			exec.bounded(postCtx, nil, PhasePostRun, unnamed(r.postRuns))
+			rootCancel() // Notify all pre runs to stop.
+		}()
+
+		// Wait for all start gates to open.
+		if err := exec.parallel(runCtx, PhaseStartGate, startGates); err != nil {
+			return
+		}
+		r.lifecycle.transit(runCtx, StateRunning)
+		waitUpgraded = notifyUpgraded(runCtx)
+
+		exec.bounded(runCtx, runCtx.Done(), PhaseMainRun, append(orderedRuns(r.runs), unnamed(runs)...))
+	}()
+	done := make(chan struct{})
+	go func() {
+		waitGroup.Wait()
+		close(done)
+	}()
+
+	// The first OS signal starts shutdown if it has not been started by a signal,
+	// e.g. a run fails and then the orchestrator sends syscall.SIGTERM.
+	// Force to return if it receives another OS signal during shutdown.
+	var signaled bool
+	for {
+		select {
+		case <-done:
+			return exec.err()
+		case sig := <-signals:
+			if signaled {
+				slog.LogAttrs(ctx, slog.LevelWarn, "Received signal during shutdown, forcing exit.", slog.Any("signal", sig))
+				r.lifecycle.emit(Event{Kind: EventSignal, Signal: sig})
+
+				return errors.Join(fmt.Errorf("receive signal %v: %w", sig, ErrShutdownForced), exec.err())
+			}
+			signaled = true
+			slog.LogAttrs(ctx, slog.LevelInfo, "Received signal, starting shutdown...", slog.Any("signal", sig))
+			r.lifecycle.emit(Event{Kind: EventSignal, Signal: sig})
+			signalCancel()
+		}
+	}
+}
+
+// execution tracks failures of runs during a single execution of Runner.Run.
+type execution struct {
+	Runner
+
+	stopping <-chan struct{}
+	shutdown context.CancelFunc
+
+	mutex  sync.Mutex
+	cause  *RunError
+	errors []*RunError
+}
+
+// executionKey is the context key of the execution, which is carried by the contexts of all runs and gates.
+type executionKey struct{}
+
+// fail records the failure of the run and starts shutdown.
+func (e *execution) fail(phase Phase, name string, err error) {
+	e.mutex.Lock()
+	runErr := &RunError{Phase: phase, Name: name, Err: err}
+	e.errors = append(e.errors, runErr)
+	if e.cause == nil {
+		select {
+		case <-e.stopping:
+		default:
+			e.cause = runErr
+		}
+	}
+	e.mutex.Unlock()
+
+	e.shutdown()
 }
 
-func parallel(ctx context.Context, runs ...func(context.Context) error) error {
+func (e *execution) err() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if len(e.errors) == 0 {
+		return nil
+	}
+
+	return &Error{Cause: e.cause, Errors: slices.Clone(e.errors)}
+}
+
+// parallel executes the runs in parallel, and cancels all runs once any run fails.
+// It records each failure and returns the first one.
+func (e *execution) parallel(ctx context.Context, phase Phase, runs []namedRun) error {
 	ctx, cancel := context.WithCancelCause(ctx)
 	defer cancel(nil)
 
@@ -133,9 +289,17 @@ func parallel(ctx context.Context, runs ...func(context.Context) error) error {
 		go func() {
 			defer waitGroup.Done()
 
-			if err := run(ctx); err != nil {
-				cancel(err)
+			e.lifecycle.emit(Event{Kind: EventRunStarted, Phase: phase, Run: run.name})
+			err := recovered(ctx, run.name, run.run)
+			if err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+				err = nil
+			}
+			e.lifecycle.emit(Event{Kind: EventRunExited, Phase: phase, Run: run.name, Err: err})
+			if err == nil {
+				return
 			}
+			e.fail(phase, run.name, err)
+			cancel(err)
 		}()
 	}
 	waitGroup.Wait()
@@ -6,7 +6,9 @@ package nilgo_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
@@ -22,6 +24,7 @@ func TestRunner_Run(t *testing.T) {
 		description string
 		runner      nilgo.Runner
 		ran         bool
+		phase       nilgo.Phase
 		err         string
 	}{
 		{
@@ -31,6 +34,7 @@ func TestRunner_Run(t *testing.T) {
 		{
 			description: "run error",
 			ran:         true,
+			phase:       nilgo.PhaseMainRun,
 			err:         "run error",
 		},
 		{
@@ -45,6 +49,7 @@ func TestRunner_Run(t *testing.T) {
 		{
 			description: "pre-run error",
 			runner:      nilgo.New(nilgo.WithPreRun(func(context.Context) error { return errors.New("pre-run error") })),
+			phase:       nilgo.PhasePreRun,
 			err:         "pre-run error",
 			ran:         true,
 		},
@@ -61,8 +66,9 @@ func TestRunner_Run(t *testing.T) {
 				return errors.New("post-run error")
 			}),
 			),
-			err: "post-run error",
-			ran: true,
+			phase: nilgo.PhasePostRun,
+			err:   "post-run error",
+			ran:   true,
 		},
 		{
 			description: "with start gate",
@@ -72,6 +78,7 @@ func TestRunner_Run(t *testing.T) {
 		{
 			description: "start gate error",
 			runner:      nilgo.New(nilgo.WithStartGate(func(context.Context) error { return errors.New("start gate error") })),
+			phase:       nilgo.PhaseStartGate,
 			err:         "start gate error",
 		},
 		{
@@ -83,6 +90,7 @@ func TestRunner_Run(t *testing.T) {
 			description: "stop gate error",
 			runner:      nilgo.New(nilgo.WithStopGate(func(context.Context) error { return errors.New("stop gate error") })),
 			ran:         true,
+			phase:       nilgo.PhaseStopGate,
 			err:         "stop gate error",
 		},
 	}
@@ -96,7 +104,7 @@ func TestRunner_Run(t *testing.T) {
 				context.Background(),
 				func(context.Context) error {
 					ran = true
-					if testcase.err != "" {
+					if testcase.phase == nilgo.PhaseMainRun {
 						return errors.New(testcase.err)
 					}
 
@@ -108,7 +116,9 @@ func TestRunner_Run(t *testing.T) {
 			if testcase.err == "" {
 				assert.NoError(t, err)
 			} else {
-				assert.EqualError(t, err, testcase.err)
+				runErr := runError(t, err)
+				assert.Equal(t, testcase.phase, runErr.Phase)
+				assert.EqualError(t, runErr.Err, testcase.err)
 			}
 		})
 	}
@@ -179,3 +189,665 @@ func TestRunner_Run_cancel(t *testing.T) {
 	assert.Equal(t, true, ran)
 	assert.Equal(t, true, time.Since(startTime) < time.Minute)
 }
+
+func TestRunner_Run_named(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mutex  sync.Mutex
+		events []string
+	)
+	record := func(event string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		events = append(events, event)
+	}
+	started := map[string]chan struct{}{
+		"db":    make(chan struct{}),
+		"cache": make(chan struct{}),
+		"http":  make(chan struct{}),
+	}
+	named := func(name string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			record("start " + name)
+			close(started[name])
+			<-ctx.Done()
+			record("stop " + name)
+
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ready := make(chan struct{})
+	runner := nilgo.New(
+		nilgo.Named("http", named("http"), nilgo.After("db", "cache")),
+		nilgo.Named("cache", named("cache"), nilgo.After("db"), nilgo.Ready(func(context.Context) error {
+			<-ready
+
+			return nil
+		})),
+		nilgo.Named("db", named("db")),
+	)
+	assert.NoError(t, runner.Run(ctx,
+		func(context.Context) error {
+			<-started["cache"] // Make sure cache has started before ready.
+			record("ready cache")
+			close(ready)
+			<-started["http"]
+			cancel()
+
+			return nil
+		},
+	))
+	assert.Equal(t, []string{
+		"start db", "start cache", "ready cache", "start http",
+		"stop http", "stop cache", "stop db",
+	}, events)
+}
+
+func TestRunner_Run_ready_error(t *testing.T) {
+	t.Parallel()
+
+	var started bool
+	runner := nilgo.New(
+		nilgo.Named("db",
+			func(ctx context.Context) error {
+				<-ctx.Done()
+
+				return nil
+			},
+			nilgo.Ready(func(context.Context) error { return errors.New("ready error") }),
+		),
+		nilgo.Named("http",
+			func(context.Context) error {
+				started = true
+
+				return nil
+			},
+			nilgo.After("db"),
+		),
+	)
+	runErr := runError(t, runner.Run(context.Background()))
+	assert.Equal(t, nilgo.PhaseMainRun, runErr.Phase)
+	assert.Equal(t, "db", runErr.Name)
+	assert.EqualError(t, runErr.Err, "ready gate: ready error")
+	assert.Equal(t, false, started)
+}
+
+func TestRunner_Run_ready_exited(t *testing.T) {
+	t.Parallel()
+
+	var started bool
+	runner := nilgo.New(
+		nilgo.Named("migrate",
+			func(context.Context) error { return nil },
+			nilgo.Ready(func(ctx context.Context) error {
+				<-ctx.Done()
+
+				return ctx.Err()
+			}),
+		),
+		nilgo.Named("api",
+			func(context.Context) error {
+				started = true
+
+				return nil
+			},
+			nilgo.After("migrate"),
+		),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	assert.NoError(t, runner.Run(ctx))
+	assert.Equal(t, nil, ctx.Err())
+	assert.Equal(t, true, started)
+}
+
+func TestNew_named(t *testing.T) {
+	t.Parallel()
+
+	run := func(context.Context) error { return nil }
+	testcases := []struct {
+		description string
+		opts        []nilgo.Option
+		err         string
+	}{
+		{
+			description: "duplicate name",
+			opts:        []nilgo.Option{nilgo.Named("db", run), nilgo.Named("db", run)},
+			err:         `nilgo: duplicate run name "db"`,
+		},
+		{
+			description: "unknown dependency",
+			opts:        []nilgo.Option{nilgo.Named("http", run, nilgo.After("db"))},
+			err:         `nilgo: run "http" depends on unknown run "db"`,
+		},
+		{
+			description: "dependency cycle",
+			opts: []nilgo.Option{
+				nilgo.Named("http", run, nilgo.After("cache")),
+				nilgo.Named("cache", run, nilgo.After("db")),
+				nilgo.Named("db", run, nilgo.After("http")),
+				nilgo.Named("log", run),
+			},
+			err: `nilgo: dependency cycle among runs "http", "cache", "db"`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				assert.Equal(t, any(testcase.err), recover())
+			}()
+			nilgo.New(testcase.opts...)
+		})
+	}
+}
+
+func TestRunner_Run_restart(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []nilgo.RunOption
+		errs        []error
+		runs        int
+		err         string
+	}{
+		{
+			description: "never",
+			errs:        []error{errors.New("run error"), nil},
+			runs:        1,
+			err:         "main run worker: run error",
+		},
+		{
+			description: "on failure",
+			opts:        []nilgo.RunOption{nilgo.Restart(nilgo.RestartOnFailure)},
+			errs:        []error{errors.New("run error"), errors.New("run error"), nil, errors.New("run error")},
+			runs:        3,
+		},
+		{
+			description: "always",
+			opts:        []nilgo.RunOption{nilgo.Restart(nilgo.RestartAlways)},
+			errs:        []error{nil, errors.New("run error"), nil, errors.New("run error")},
+			runs:        4,
+			err:         "main run worker: exhausted 3 restarts within 1m0s: run error",
+		},
+		{
+			description: "exhausted",
+			opts: []nilgo.RunOption{
+				nilgo.Restart(nilgo.RestartOnFailure),
+				nilgo.RestartLimit(1, time.Minute),
+			},
+			errs: []error{errors.New("run error"), errors.New("last error"), nil},
+			runs: 2,
+			err:  "main run worker: exhausted 1 restarts within 1m0s: last error",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var runs int
+			runner := nilgo.New(
+				nilgo.Named("worker",
+					func(context.Context) error {
+						runs++
+
+						return testcase.errs[runs-1]
+					},
+					append([]nilgo.RunOption{
+						nilgo.RestartBackoff(time.Millisecond, time.Millisecond),
+						nilgo.RestartLimit(3, time.Minute),
+					}, testcase.opts...)...,
+				),
+			)
+			err := runner.Run(context.Background())
+
+			assert.Equal(t, testcase.runs, runs)
+			if testcase.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testcase.err)
+			}
+		})
+	}
+}
+
+func TestRunner_Run_restartOnPanic(t *testing.T) {
+	t.Parallel()
+
+	var runs int
+	runner := nilgo.New(
+		nilgo.Named("worker",
+			func(context.Context) error {
+				runs++
+				if runs == 1 {
+					panic("run panic")
+				}
+
+				return nil
+			},
+			nilgo.Restart(nilgo.RestartOnFailure),
+			nilgo.RestartBackoff(time.Millisecond, time.Millisecond),
+		),
+	)
+	err := runner.Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, runs)
+}
+
+func TestRunner_Run_shutdownTimeout(t *testing.T) {
+	t.Parallel()
+
+	blocked := func(release chan struct{}) func(context.Context) error {
+		return func(context.Context) error {
+			<-release
+
+			return nil
+		}
+	}
+
+	testcases := []struct {
+		description string
+		opts        func(chan struct{}) []nilgo.Option
+		run         func(chan struct{}) func(context.Context) error
+		phase       nilgo.Phase
+	}{
+		{
+			description: "stop gate",
+			opts: func(release chan struct{}) []nilgo.Option {
+				return []nilgo.Option{nilgo.WithStopGate(blocked(release))}
+			},
+			phase: nilgo.PhaseStopGate,
+		},
+		{
+			description: "main run",
+			run:         blocked,
+			phase:       nilgo.PhaseMainRun,
+		},
+		{
+			description: "post run",
+			opts: func(release chan struct{}) []nilgo.Option {
+				return []nilgo.Option{nilgo.WithPostRun(blocked(release))}
+			},
+			phase: nilgo.PhasePostRun,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			release := make(chan struct{})
+			defer close(release)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			opts := []nilgo.Option{nilgo.WithShutdownTimeout(10 * time.Millisecond)}
+			if testcase.opts != nil {
+				opts = append(opts, testcase.opts(release)...)
+			}
+			runs := []func(context.Context) error{
+				func(context.Context) error {
+					cancel()
+
+					return nil
+				},
+			}
+			if testcase.run != nil {
+				runs = append(runs, testcase.run(release))
+			}
+			err := nilgo.New(opts...).Run(ctx, runs...)
+
+			assert.Equal(t, true, errors.Is(err, nilgo.ErrShutdownTimeout))
+			runErr := runError(t, err)
+			assert.Equal(t, testcase.phase, runErr.Phase)
+			assert.Equal(t, "nilgo_test.TestRunner_Run_shutdownTimeout.func1.1", runErr.Name)
+		})
+	}
+}
+
+func TestRunner_Run_forced(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	runner := nilgo.New(
+		nilgo.WithStopGate(func(context.Context) error {
+			if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+				return err
+			}
+			<-release
+
+			return nil
+		}),
+	)
+	err := runner.Run(context.Background(),
+		func(ctx context.Context) error {
+			if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+				return err
+			}
+			<-ctx.Done()
+
+			return nil
+		},
+	)
+	assert.Equal(t, true, errors.Is(err, nilgo.ErrShutdownForced))
+	assert.EqualError(t, err, "receive signal interrupt: shutdown forced")
+}
+
+func TestRunner_Run_errorThenSignal(t *testing.T) {
+	var flushed bool
+	runner := nilgo.New(
+		nilgo.WithStopGate(func(context.Context) error {
+			return syscall.Kill(os.Getpid(), syscall.SIGTERM)
+		}),
+		nilgo.WithPostRun(func(context.Context) error {
+			flushed = true
+
+			return nil
+		}),
+	)
+	received := make(chan struct{})
+	defer runner.Subscribe(func(event nilgo.Event) {
+		if event.Kind == nilgo.EventSignal {
+			close(received)
+		}
+	})()
+	err := runner.Run(context.Background(),
+		func(ctx context.Context) error {
+			<-received // Wait for the signal to make sure it's received during shutdown.
+			<-ctx.Done()
+
+			return nil
+		},
+		func(context.Context) error { return errors.New("run error") },
+	)
+	assert.Equal(t, false, errors.Is(err, nilgo.ErrShutdownForced))
+	assert.Equal(t, true, flushed)
+	runErr := runError(t, err)
+	assert.EqualError(t, runErr.Err, "run error")
+}
+
+func TestRunner_Run_errors(t *testing.T) {
+	t.Parallel()
+
+	listenErr := errors.New("listen error")
+	flushErr := errors.New("flush error")
+	runner := nilgo.New(
+		nilgo.WithPostRun(func(context.Context) error { return flushErr }),
+		nilgo.Named("http", func(context.Context) error { return listenErr }),
+	)
+	err := runner.Run(context.Background())
+
+	var runnerErr *nilgo.Error
+	assert.Equal(t, true, errors.As(err, &runnerErr))
+	assert.Equal(t, &nilgo.RunError{Phase: nilgo.PhaseMainRun, Name: "http", Err: listenErr}, runnerErr.Cause)
+	assert.Equal(t, 2, len(runnerErr.Errors))
+	assert.Equal(t, true, errors.Is(err, listenErr))
+	assert.Equal(t, true, errors.Is(err, flushErr))
+	assert.EqualError(t, err, "main run http: listen error\npost run nilgo_test.TestRunner_Run_errors.func1: flush error")
+}
+
+func TestRunner_Run_panic(t *testing.T) {
+	t.Parallel()
+
+	boom := func(context.Context) error { panic("boom") }
+	testcases := []struct {
+		description string
+		opts        []nilgo.Option
+		run         func(context.Context) error
+		phase       nilgo.Phase
+	}{
+		{
+			description: "pre-run",
+			opts:        []nilgo.Option{nilgo.WithPreRun(boom)},
+			phase:       nilgo.PhasePreRun,
+		},
+		{
+			description: "start gate",
+			opts:        []nilgo.Option{nilgo.WithStartGate(boom)},
+			phase:       nilgo.PhaseStartGate,
+		},
+		{
+			description: "main run",
+			run:         boom,
+			phase:       nilgo.PhaseMainRun,
+		},
+		{
+			description: "stop gate",
+			opts:        []nilgo.Option{nilgo.WithStopGate(boom)},
+			phase:       nilgo.PhaseStopGate,
+		},
+		{
+			description: "post run",
+			opts:        []nilgo.Option{nilgo.WithPostRun(boom)},
+			phase:       nilgo.PhasePostRun,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var flushed bool
+			opts := append([]nilgo.Option{nilgo.WithPostRun(func(context.Context) error {
+				flushed = true
+
+				return nil
+			})}, testcase.opts...)
+			runs := []func(context.Context) error{func(context.Context) error { return nil }}
+			if testcase.run != nil {
+				runs = append(runs, testcase.run)
+			}
+			err := nilgo.New(opts...).Run(context.Background(), runs...)
+
+			assert.Equal(t, true, flushed)
+			assert.Equal(t, true, errors.Is(err, nilgo.ErrPanic))
+			runErr := runError(t, err)
+			assert.Equal(t, testcase.phase, runErr.Phase)
+			assert.EqualError(t, runErr.Err, "panic: boom")
+		})
+	}
+}
+
+func runError(t *testing.T, err error) *nilgo.RunError {
+	t.Helper()
+
+	var runnerErr *nilgo.Error
+	if !errors.As(err, &runnerErr) || len(runnerErr.Errors) != 1 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return runnerErr.Errors[0]
+}
+
+func TestRunner_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mutex  sync.Mutex
+		events []string
+	)
+	runner := nilgo.New(nilgo.Named("worker", func(ctx context.Context) error {
+		runner, ok := nilgo.FromContext(ctx)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, nilgo.StateRunning, runner.State())
+
+		return errors.New("worker error")
+	}))
+	unsubscribe := runner.Subscribe(func(event nilgo.Event) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch event.Kind {
+		case nilgo.EventStateChanged:
+			events = append(events, event.State.String())
+		case nilgo.EventRunStarted:
+			events = append(events, "start "+event.Run)
+		case nilgo.EventRunExited:
+			events = append(events, fmt.Sprintf("exit %s: %v", event.Run, event.Err))
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	assert.Equal(t, nilgo.StateIdle, runner.State())
+	runErr := runError(t, runner.Run(context.Background()))
+	assert.EqualError(t, runErr, "main run worker: worker error")
+	assert.Equal(t, nilgo.StateStopped, runner.State())
+	assert.Equal(t, []string{
+		"starting", "running", "start worker", "exit worker: worker error",
+		"stopping", "draining", "finishing", "stopped",
+	}, events)
+}
+
+func TestRunner_Subscribe_unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	runner := nilgo.New()
+	var (
+		count       int
+		unsubscribe func()
+	)
+	unsubscribe = runner.Subscribe(func(nilgo.Event) {
+		count++
+		unsubscribe()
+	})
+	assert.NoError(t, runner.Run(context.Background()))
+	assert.Equal(t, 1, count)
+}
+
+func TestRunner_Run_reload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloaded := make(chan string, 2)
+	var (
+		mutex  sync.Mutex
+		events []nilgo.EventKind
+	)
+	runner := nilgo.New(nilgo.WithReloadSignals(syscall.SIGHUP), nilgo.WithReloadHook(func(context.Context) error {
+		reloaded <- "hook"
+
+		return nil
+	}))
+	defer runner.Subscribe(func(event nilgo.Event) {
+		if event.Kind == nilgo.EventReloadStarted || event.Kind == nilgo.EventReloadFinished {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			events = append(events, event.Kind)
+		}
+	})()
+	assert.NoError(t, runner.Run(ctx,
+		func(ctx context.Context) error {
+			runner, ok := nilgo.FromContext(ctx)
+			assert.Equal(t, true, ok)
+			defer runner.OnReload(func(context.Context) error {
+				reloaded <- "registered"
+
+				return nil
+			})()
+
+			if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+				return err
+			}
+			first, second := <-reloaded, <-reloaded
+			assert.Equal(t, true, first != second)
+			cancel()
+
+			return nil
+		},
+	))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, []nilgo.EventKind{nilgo.EventReloadStarted, nilgo.EventReloadFinished}, events)
+}
+
+func TestRunner_Run_signalHandler(t *testing.T) {
+	handled := make(chan os.Signal, 1)
+	runner := nilgo.New(
+		nilgo.WithShutdownSignals(syscall.SIGUSR2),
+		nilgo.WithSignalHandler(func(context.Context) error {
+			handled <- syscall.SIGUSR1
+
+			return errors.New("handler error")
+		}, syscall.SIGUSR1),
+	)
+	assert.NoError(t, runner.Run(context.Background(),
+		func(ctx context.Context) error {
+			if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+				return err
+			}
+			assert.Equal(t, os.Signal(syscall.SIGUSR1), <-handled)
+			if err := syscall.Kill(os.Getpid(), syscall.SIGUSR2); err != nil {
+				return err
+			}
+			<-ctx.Done()
+
+			return nil
+		},
+	))
+}
+
+func TestRunner_Reload(t *testing.T) {
+	t.Parallel()
+
+	runner := nilgo.New(
+		nilgo.WithReloadHook(
+			func(context.Context) error { return nil },
+			func(context.Context) error { return errors.New("reload error") },
+		),
+	)
+	err := runner.Reload(context.Background())
+	assert.EqualError(t, err, "reload hook nilgo_test.TestRunner_Reload.func2: reload error")
+}
+
+func TestDump(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, nilgo.Dump(context.Background()))
+}
+
+func TestRunner_Run_restartOn(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	triggers := make(chan func(), 1)
+	starts := make(chan struct{}, 2)
+	runner := nilgo.New(
+		nilgo.Named("app", func(ctx context.Context) error {
+			starts <- struct{}{}
+			<-ctx.Done()
+
+			return nil
+		}, nilgo.RestartOn(func(restart func()) func() {
+			triggers <- restart
+
+			return func() {}
+		})),
+	)
+	done := make(chan error, 1)
+	go func() {
+		done <- runner.Run(ctx)
+	}()
+
+	<-starts
+	(<-triggers)()
+	<-starts
+	<-triggers
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expression is a parsed cron expression.
+//
+// To create a new Expression, call [Parse].
+type Expression struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar indicate the day of month and day of week start with `*`, e.g. `*/2`,
+	// since the day matches either of them if both are restricted.
+	domStar, dowStar bool
+}
+
+// Parse parses the standard cron expression with five fields:
+// minute (0-59), hour (0-23), day of month (1-31), month (1-12 or JAN-DEC) and day of week (0-7 or SUN-SAT,
+// which both 0 and 7 are Sunday). Each field supports `*`, lists like `1,15`, ranges like `1-5`
+// and steps like `*/15` or `0-30/10`.
+// It also supports the descriptors `@yearly`, `@annually`, `@monthly`, `@weekly`, `@daily`, `@midnight`
+// and `@hourly`.
+func Parse(expression string) (Expression, error) {
+	if descriptor, ok := descriptors[strings.ToLower(strings.TrimSpace(expression))]; ok {
+		expression = descriptor
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return Expression{}, fmt.Errorf("%w %q: expected %d fields", errInvalidExpression, expression, len(cronFields))
+	}
+
+	var (
+		expr Expression
+		bits = []*uint64{&expr.minute, &expr.hour, &expr.dom, &expr.month, &expr.dow}
+	)
+	for i, field := range fields {
+		value, err := cronFields[i].parse(field)
+		if err != nil {
+			return Expression{}, fmt.Errorf("%w %q: %s: %w", errInvalidExpression, expression, cronFields[i].name, err)
+		}
+		*bits[i] = value
+	}
+	expr.domStar = strings.HasPrefix(fields[2], "*")
+	expr.dowStar = strings.HasPrefix(fields[4], "*")
+	// Both 0 and 7 are Sunday.
+	if expr.dow&(1<<7) != 0 {
+		expr.dow |= 1
+	}
+
+	return expr, nil
+}
+
+// Next returns the next time after t which matches the expression, in the location of t.
+// It returns the zero time if there is no matched time in five years, e.g. `0 0 30 2 *`.
+func (e Expression) Next(t time.Time) time.Time { //nolint:cyclop,funlen
+	// Start from the next minute.
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	yearLimit := t.Year() + 5 //nolint:mnd
+	// added indicates the time has been moved forward, so the smaller units are reset to zero.
+	added := false
+
+	for t.Year() <= yearLimit {
+		for e.month&(1<<uint(t.Month())) == 0 {
+			added = true
+			t = startOfDay(t.Year(), t.Month()+1, 1, t.Location())
+		}
+
+		wrapped := false
+		for !e.dayMatches(t) {
+			added = true
+			month := t.Month()
+			t = startOfDay(t.Year(), t.Month(), t.Day()+1, t.Location())
+			if t.Month() != month {
+				wrapped = true
+
+				break
+			}
+		}
+		if wrapped {
+			continue
+		}
+
+		for e.hour&(1<<uint(t.Hour())) == 0 {
+			if !added {
+				added = true
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+			}
+			// Compare the calendar date instead of checking the hour 0,
+			// which does not exist on the day when daylight saving time starts at midnight.
+			day := t.Day()
+			t = t.Add(time.Hour)
+			if t.Day() != day {
+				wrapped = true
+
+				break
+			}
+		}
+		if wrapped {
+			continue
+		}
+
+		for e.minute&(1<<uint(t.Minute())) == 0 {
+			added = true
+			hour := t.Hour()
+			t = t.Add(time.Minute)
+			if t.Hour() != hour {
+				wrapped = true
+
+				break
+			}
+		}
+		if wrapped {
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// startOfDay returns the first time of the day, which is not midnight
+// if daylight saving time starts at midnight, e.g. America/Sao_Paulo before 2019.
+func startOfDay(year int, month time.Month, day int, location *time.Location) time.Time {
+	// The noon always exists, and normalizes the date, e.g. the 32nd day of month.
+	noon := time.Date(year, month, day, 12, 0, 0, 0, location) //nolint:mnd
+	midnight := time.Date(noon.Year(), noon.Month(), noon.Day(), 0, 0, 0, 0, location)
+	if midnight.Day() != noon.Day() {
+		// The midnight in the gap is normalized with the offset after the transition, which falls in the day before.
+		// Shift it with the offset change to the end of the gap.
+		_, before := midnight.Zone()
+		_, after := noon.Zone()
+		midnight = midnight.Add(time.Duration(after-before) * time.Second)
+	}
+
+	return midnight
+}
+
+func (e Expression) dayMatches(t time.Time) bool {
+	domMatch := e.dom&(1<<uint(t.Day())) != 0
+	dowMatch := e.dow&(1<<uint(t.Weekday())) != 0
+	if e.domStar || e.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart) //nolint:err113
+			}
+		}
+
+		start, end := f.min, f.max
+		if rangePart != "*" {
+			low, high, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = f.value(low); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = f.value(high); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				end = f.max
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q", rangePart) //nolint:err113
+			}
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+func (f cronField) value(value string) (int, error) {
+	if i, ok := f.names[strings.ToLower(value)]; ok {
+		return i, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil || i < f.min || i > f.max {
+		return 0, fmt.Errorf("invalid value %q, expected %d-%d", value, f.min, f.max) //nolint:err113
+	}
+
+	return i, nil
+}
+
+var (
+	errInvalidExpression = errors.New("invalid cron expression")
+
+	//nolint:gochecknoglobals
+	cronFields = []cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: map[string]int{
+			"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+			"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+		}},
+		{name: "day of week", min: 0, max: 7, names: map[string]int{
+			"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+		}},
+	}
+	//nolint:gochecknoglobals
+	descriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
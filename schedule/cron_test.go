@@ -0,0 +1,114 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nil-go/nilgo/schedule"
+	"github.com/nil-go/nilgo/schedule/internal/assert"
+)
+
+func TestExpression_Next(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, time.May, 17, 10, 30, 15, 0, time.UTC) // Friday.
+	testcases := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{expression: "* * * * *", expected: time.Date(2024, time.May, 17, 10, 31, 0, 0, time.UTC)},
+		{expression: "*/15 * * * *", expected: time.Date(2024, time.May, 17, 10, 45, 0, 0, time.UTC)},
+		{expression: "0 * * * *", expected: time.Date(2024, time.May, 17, 11, 0, 0, 0, time.UTC)},
+		{expression: "@hourly", expected: time.Date(2024, time.May, 17, 11, 0, 0, 0, time.UTC)},
+		{expression: "30 2 * * *", expected: time.Date(2024, time.May, 18, 2, 30, 0, 0, time.UTC)},
+		{expression: "0 9-17/4 * * *", expected: time.Date(2024, time.May, 17, 13, 0, 0, 0, time.UTC)},
+		{expression: "0 0 * * MON", expected: time.Date(2024, time.May, 20, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 * * 7", expected: time.Date(2024, time.May, 19, 0, 0, 0, 0, time.UTC)},
+		{expression: "@weekly", expected: time.Date(2024, time.May, 19, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 1,15 * *", expected: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 1 * 5", expected: time.Date(2024, time.May, 24, 0, 0, 0, 0, time.UTC)},
+		// The day of month starts with `*`, so the day matches both fields, e.g. odd Monday.
+		{expression: "0 0 */2 * 1", expected: time.Date(2024, time.May, 27, 0, 0, 0, 0, time.UTC)},
+		// The day of week starts with `*`, so the day matches both fields, e.g. the 1st on weekend.
+		{expression: "0 0 1 * */6", expected: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{expression: "@yearly", expected: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 29 feb *", expected: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 30 2 *", expected: time.Time{}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.expression, func(t *testing.T) {
+			t.Parallel()
+
+			expression, err := schedule.Parse(testcase.expression)
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expected, expression.Next(from))
+		})
+	}
+}
+
+func TestExpression_Next_dst(t *testing.T) {
+	t.Parallel()
+
+	// Daylight saving time started at midnight in Sao Paulo, e.g. 2018-11-04 00:00 did not exist.
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	assert.NoError(t, err)
+	testcases := []struct {
+		expression string
+		from       time.Time
+		expected   time.Time
+	}{
+		{
+			expression: "30 1 3 11 *",
+			from:       time.Date(2018, time.November, 3, 2, 0, 0, 0, location),
+			expected:   time.Date(2019, time.November, 3, 1, 30, 0, 0, location),
+		},
+		{
+			expression: "0 0 * * *",
+			from:       time.Date(2018, time.November, 3, 12, 0, 0, 0, location),
+			expected:   time.Date(2018, time.November, 5, 0, 0, 0, 0, location),
+		},
+		{
+			expression: "0 * 4 11 *",
+			from:       time.Date(2018, time.November, 3, 12, 0, 0, 0, location),
+			expected:   time.Date(2018, time.November, 4, 1, 0, 0, 0, location),
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.expression, func(t *testing.T) {
+			t.Parallel()
+
+			expression, err := schedule.Parse(testcase.expression)
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expected, expression.Next(testcase.from))
+		})
+	}
+}
+
+func TestParse_error(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		expression string
+		err        string
+	}{
+		{expression: "* * * *", err: `invalid cron expression "* * * *": expected 5 fields`},
+		{expression: "60 * * * *", err: `invalid cron expression "60 * * * *": minute: invalid value "60", expected 0-59`},
+		{expression: "* * * * */0", err: `invalid cron expression "* * * * */0": day of week: invalid step "0"`},
+		{expression: "* 5-1 * * *", err: `invalid cron expression "* 5-1 * * *": hour: invalid range "5-1"`},
+		{expression: "* * * foo *", err: `invalid cron expression "* * * foo *": month: invalid value "foo", expected 1-12`},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.expression, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := schedule.Parse(testcase.expression)
+			assert.EqualError(t, err, testcase.err)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+module github.com/nil-go/nilgo/schedule
+
+go 1.22
+
+require (
+	github.com/nil-go/nilgo v0.3.0
+	github.com/nil-go/sloth v0.3.0
+	go.opentelemetry.io/otel v1.32.0
+	go.opentelemetry.io/otel/trace v1.32.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/otel/metric v1.32.0 // indirect
+)
+
+// The modules in this repository are developed together and tagged with the same version at the same commit,
+// root first (see .github/workflows/release.yml). The replacement only applies to the development here,
+// since Go ignores it in dependencies.
+replace github.com/nil-go/nilgo => ../
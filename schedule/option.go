@@ -0,0 +1,78 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package schedule
+
+import "time"
+
+// Overlap is the policy when the execution is due while the previous execution is still running.
+type Overlap int
+
+const (
+	// OverlapSkip skips the execution which is due while the previous execution is still running.
+	OverlapSkip Overlap = iota
+	// OverlapQueue queues the execution which is due while the previous execution is still running,
+	// and executes it after the previous execution returns.
+	OverlapQueue
+	// OverlapCancel cancels the context of the previous execution which is still running,
+	// and executes the new execution after the previous execution returns.
+	OverlapCancel
+)
+
+// WithName provides the name of the job, which is used in the trace span and logs.
+//
+// By default, it's the function name of the job.
+func WithName(name string) Option {
+	return func(options *options) {
+		options.name = name
+	}
+}
+
+// WithJitter delays each execution by a random duration in [0, jitter),
+// so the executions from multiple instances do not hit the dependencies at the same time.
+//
+// By default, it has no jitter.
+func WithJitter(jitter time.Duration) Option {
+	return func(options *options) {
+		options.jitter = jitter
+	}
+}
+
+// WithOverlap provides the policy when the execution is due while the previous execution is still running.
+//
+// By default, it's OverlapSkip.
+func WithOverlap(overlap Overlap) Option {
+	return func(options *options) {
+		options.overlap = overlap
+	}
+}
+
+// WithTimeout provides the timeout of each execution.
+//
+// By default, the execution has no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *options) {
+		options.timeout = timeout
+	}
+}
+
+// WithLocation provides the location in which the cron expression is interpreted.
+//
+// By default, it's time.Local.
+func WithLocation(location *time.Location) Option {
+	return func(options *options) {
+		options.location = location
+	}
+}
+
+type (
+	// Option configures the scheduled run with optional parameters.
+	Option  func(*options)
+	options struct {
+		name     string
+		jitter   time.Duration
+		overlap  Overlap
+		timeout  time.Duration
+		location *time.Location
+	}
+)
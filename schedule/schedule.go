@@ -0,0 +1,204 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package schedule turns periodic jobs into runs managed by the Runner,
+// which execute the job at a fixed interval or the times matching a cron expression.
+//
+// Each execution has a trace span and a log buffer for sampling.Handler like HTTP and gRPC requests,
+// and it recovers from panic. The in-flight executions drain when the run stops.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/nil-go/sloth/sampling"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nil-go/nilgo/internal/recovery"
+)
+
+// Every returns a run which executes the job at the fixed interval, starting one interval after the run starts.
+//
+// The run blocks until ctx is done, and then waits for the in-flight executions to return.
+func Every(interval time.Duration, job func(context.Context) error, opts ...Option) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if interval <= 0 {
+			return fmt.Errorf("%w: %s", errInvalidInterval, interval)
+		}
+
+		return newScheduler(job, opts).run(ctx, func(t time.Time) time.Time { return t.Add(interval) })
+	}
+}
+
+// Cron returns a run which executes the job at the times matching the cron expression (see [Parse]),
+// in the location provided by [WithLocation].
+//
+// The run returns error immediately if the expression is invalid.
+// Otherwise, it blocks until ctx is done, and then waits for the in-flight executions to return.
+func Cron(expression string, job func(context.Context) error, opts ...Option) func(context.Context) error {
+	return func(ctx context.Context) error {
+		expr, err := Parse(expression)
+		if err != nil {
+			return err
+		}
+
+		return newScheduler(job, opts).run(ctx, expr.Next)
+	}
+}
+
+type scheduler struct {
+	options
+	job func(context.Context) error
+
+	waitGroup sync.WaitGroup
+	mutex     sync.Mutex
+	running   bool
+	pending   int
+	cancel    context.CancelFunc
+}
+
+func newScheduler(job func(context.Context) error, opts []Option) *scheduler {
+	scheduler := &scheduler{job: job}
+	for _, opt := range opts {
+		opt(&scheduler.options)
+	}
+	if scheduler.name == "" {
+		scheduler.name = runtime.FuncForPC(reflect.ValueOf(job).Pointer()).Name()
+	}
+	if scheduler.location == nil {
+		scheduler.location = time.Local
+	}
+
+	return scheduler
+}
+
+// run dispatches the executions at the times returned by next, until ctx is done.
+func (s *scheduler) run(ctx context.Context, next func(time.Time) time.Time) error {
+	defer func() {
+		s.mutex.Lock()
+		s.pending = 0 // Drop the queued executions.
+		s.mutex.Unlock()
+		s.waitGroup.Wait()
+	}()
+
+	scheduled := time.Now().In(s.location)
+	for {
+		scheduled = next(scheduled)
+		if now := time.Now().In(s.location); scheduled.Before(now) {
+			// Skip the missed executions, e.g. the system has been suspended.
+			scheduled = next(now)
+		}
+		if scheduled.IsZero() {
+			slog.LogAttrs(ctx, slog.LevelWarn, "Scheduled job has no next execution.", slog.String("job", s.name))
+			<-ctx.Done()
+
+			return nil
+		}
+		delay := time.Until(scheduled)
+		if s.jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(s.jitter))) //nolint:gosec
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil
+		case <-timer.C:
+			s.dispatch(ctx)
+		}
+	}
+}
+
+// dispatch starts an execution according to the overlap policy.
+func (s *scheduler) dispatch(ctx context.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running {
+		switch s.overlap {
+		case OverlapQueue:
+			s.pending++
+		case OverlapCancel:
+			s.cancel()
+			s.pending = 1
+		default:
+			slog.LogAttrs(ctx, slog.LevelWarn, "Scheduled job is skipped since the previous execution is still running.",
+				slog.String("job", s.name),
+			)
+		}
+
+		return
+	}
+
+	s.running = true
+	execCtx := s.executionContext(ctx)
+	s.waitGroup.Add(1)
+	go func() {
+		defer s.waitGroup.Done()
+
+		for {
+			s.execute(execCtx)
+
+			s.mutex.Lock()
+			s.cancel()
+			if s.pending == 0 {
+				s.running = false
+				s.mutex.Unlock()
+
+				return
+			}
+			s.pending--
+			execCtx = s.executionContext(ctx)
+			s.mutex.Unlock()
+		}
+	}()
+}
+
+// executionContext creates the context for the next execution, which can be canceled by OverlapCancel.
+// It is not canceled when the run stops, so the in-flight execution can drain.
+// It must be called with the mutex locked.
+func (s *scheduler) executionContext(ctx context.Context) context.Context {
+	ctx, s.cancel = context.WithCancel(context.WithoutCancel(ctx))
+
+	return ctx
+}
+
+// execute executes the job once with trace span, log buffer, timeout and panic recovery.
+func (s *scheduler) execute(ctx context.Context) {
+	ctx, span := otel.Tracer("github.com/nil-go/nilgo/schedule").Start(ctx, s.name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attribute.String("schedule.job", s.name)),
+	)
+	defer span.End()
+	ctx, put := sampling.WithBuffer(ctx)
+	defer put()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	err := recovery.Recover(ctx, s.job, slog.String("job", s.name))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if !errors.Is(err, context.Canceled) {
+			slog.LogAttrs(ctx, slog.LevelError, "Scheduled job failed.", slog.String("job", s.name), slog.Any("error", err))
+		}
+	}
+}
+
+var errInvalidInterval = errors.New("invalid interval")
@@ -0,0 +1,183 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package schedule_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nil-go/nilgo/schedule"
+	"github.com/nil-go/nilgo/schedule/internal/assert"
+)
+
+func TestEvery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var count atomic.Int32
+	err := schedule.Every(10*time.Millisecond, func(context.Context) error {
+		if count.Add(1) == 3 {
+			cancel()
+		}
+
+		return errors.New("job error")
+	}, schedule.WithJitter(time.Millisecond))(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), count.Load())
+}
+
+func TestEvery_panic(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var count atomic.Int32
+	err := schedule.Every(10*time.Millisecond, func(context.Context) error {
+		if count.Add(1) == 2 {
+			cancel()
+		}
+		panic("boom")
+	})(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), count.Load())
+}
+
+func TestEvery_invalid(t *testing.T) {
+	t.Parallel()
+
+	err := schedule.Every(0, func(context.Context) error { return nil })(context.Background())
+	assert.EqualError(t, err, "invalid interval: 0s")
+}
+
+func TestCron_invalid(t *testing.T) {
+	t.Parallel()
+
+	err := schedule.Cron("* * *", func(context.Context) error { return nil })(context.Background())
+	assert.EqualError(t, err, `invalid cron expression "* * *": expected 5 fields`)
+}
+
+func TestEvery_overlap(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		overlap     schedule.Overlap
+		// executions is the number of executions shortly after the first execution is released.
+		executions int32
+		canceled   bool
+	}{
+		{
+			description: "skip",
+			overlap:     schedule.OverlapSkip,
+			executions:  1,
+		},
+		{
+			description: "queue",
+			overlap:     schedule.OverlapQueue,
+			executions:  4,
+		},
+		{
+			description: "cancel",
+			overlap:     schedule.OverlapCancel,
+			executions:  4,
+			canceled:    true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var (
+				count    atomic.Int32
+				canceled atomic.Bool
+				release  = make(chan struct{})
+				done     = make(chan error, 1)
+			)
+			go func() {
+				done <- schedule.Every(50*time.Millisecond, func(ctx context.Context) error {
+					if count.Add(1) == 1 {
+						select {
+						case <-ctx.Done():
+							canceled.Store(true)
+						case <-release:
+						}
+					}
+
+					return nil
+				}, schedule.WithOverlap(testcase.overlap))(ctx)
+			}()
+
+			// The first execution blocks while the next three executions are due at 100ms, 150ms and 200ms.
+			time.Sleep(220 * time.Millisecond)
+			close(release)
+			// Wait for the queued executions before the next execution is due at 250ms.
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+			assert.NoError(t, <-done)
+			assert.Equal(t, testcase.executions, count.Load())
+			assert.Equal(t, testcase.canceled, canceled.Load())
+		})
+	}
+}
+
+func TestEvery_timeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 1)
+	assert.NoError(t, schedule.Every(10*time.Millisecond, func(ctx context.Context) error {
+		defer cancel()
+
+		<-ctx.Done()
+		select {
+		case errs <- ctx.Err():
+		default:
+		}
+
+		return ctx.Err()
+	}, schedule.WithTimeout(10*time.Millisecond))(ctx))
+	assert.Equal(t, context.DeadlineExceeded, <-errs)
+}
+
+func TestEvery_drain(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		started  = make(chan struct{})
+		finished atomic.Bool
+		done     = make(chan error, 1)
+	)
+	go func() {
+		done <- schedule.Every(10*time.Millisecond, func(ctx context.Context) error {
+			if finished.Load() {
+				return nil
+			}
+			close(started)
+			time.Sleep(50 * time.Millisecond)
+			finished.Store(ctx.Err() == nil)
+
+			return nil
+		})(ctx)
+	}()
+
+	<-started
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Equal(t, true, finished.Load())
+}
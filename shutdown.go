@@ -0,0 +1,127 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrShutdownTimeout is returned by Runner.Run if runs are still blocked
+	// after the timeout provided by [WithShutdownTimeout].
+	ErrShutdownTimeout = errors.New("shutdown timeout")
+	// ErrShutdownForced is returned by Runner.Run if it receives another OS signal during shutdown.
+	ErrShutdownForced = errors.New("shutdown forced")
+)
+
+// bounded executes the runs in parallel, and waits them return
+// at most the shutdown timeout after the deadline channel is closed.
+// If the deadline channel is nil, the shutdown timeout starts immediately.
+//
+// It logs the blocked runs with goroutine stacks and records them failed
+// with ErrShutdownTimeout if the timeout exceeds.
+func (e *execution) bounded(ctx context.Context, deadline <-chan struct{}, phase Phase, runs []namedRun) {
+	if e.shutdownTimeout <= 0 {
+		_ = e.parallel(ctx, phase, runs)
+
+		return
+	}
+
+	var (
+		mutex   sync.Mutex
+		pending = make(map[int]string, len(runs))
+	)
+	tracked := make([]namedRun, 0, len(runs))
+	for i, run := range runs {
+		pending[i] = run.name
+		tracked = append(tracked, namedRun{name: run.name, run: func(ctx context.Context) error {
+			defer func() {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				delete(pending, i)
+			}()
+
+			return run.run(ctx)
+		}})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		_ = e.parallel(ctx, phase, tracked)
+	}()
+	if deadline != nil {
+		select {
+		case <-done:
+			return
+		case <-deadline:
+		}
+	}
+
+	timer := time.NewTimer(e.shutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return
+	case <-timer.C:
+	}
+
+	mutex.Lock()
+	names := make([]string, 0, len(pending))
+	for _, name := range pending {
+		names = append(names, name)
+	}
+	mutex.Unlock()
+	slices.Sort(names)
+	slog.LogAttrs(ctx, slog.LevelError, "Shutdown timeout, runs are still blocked.",
+		slog.String("phase", string(phase)),
+		slog.Duration("timeout", e.shutdownTimeout),
+		slog.Any("runs", names),
+		slog.String("stacks", stacks()),
+	)
+	for _, name := range names {
+		e.fail(phase, name, ErrShutdownTimeout)
+	}
+}
+
+// shutdownContext returns a copy of ctx with the shutdown timeout if it's provided.
+func (r Runner) shutdownContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.shutdownTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, r.shutdownTimeout)
+}
+
+func stacks() string {
+	buf := make([]byte, 64<<10) //nolint:mnd
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf)) //nolint:mnd
+	}
+}
+
+// unnamed converts the runs into named runs with their function names.
+func unnamed(runs []func(context.Context) error) []namedRun {
+	named := make([]namedRun, 0, len(runs))
+	for _, run := range runs {
+		name := runtime.FuncForPC(reflect.ValueOf(run).Pointer()).Name()
+		named = append(named, namedRun{name: name[strings.LastIndexByte(name, '/')+1:], run: run})
+	}
+
+	return named
+}
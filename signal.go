@@ -0,0 +1,106 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"runtime"
+	"slices"
+	"syscall"
+)
+
+// Dump writes the goroutine dump and the heap summary into logs.
+//
+// It can be registered for OS signals with [WithSignalHandler], e.g. syscall.SIGUSR1.
+func Dump(ctx context.Context) error {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	slog.LogAttrs(ctx, slog.LevelInfo, "Dump goroutines and heap.",
+		slog.Int("goroutines", runtime.NumGoroutine()),
+		slog.Group("heap",
+			slog.Uint64("alloc", stats.HeapAlloc),
+			slog.Uint64("inuse", stats.HeapInuse),
+			slog.Uint64("idle", stats.HeapIdle),
+			slog.Uint64("released", stats.HeapReleased),
+			slog.Uint64("objects", stats.HeapObjects),
+			slog.Uint64("sys", stats.Sys),
+			slog.Uint64("gc", uint64(stats.NumGC)),
+		),
+		slog.String("stacks", stacks()),
+	)
+
+	return nil
+}
+
+type signalHandler struct {
+	signals []os.Signal
+	handler func(context.Context) error
+}
+
+func (r Runner) shutdownSignalSet() []os.Signal {
+	if r.shutdownSignals == nil {
+		return []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+
+	return r.shutdownSignals
+}
+
+// handleSignals registers the upgrade and reload handlers and the handlers provided by [WithSignalHandler],
+// and returns the function which dispatches the OS signals to the handlers until the context is done.
+// The shutdown signals are not dispatched.
+func (r Runner) handleSignals(ctx context.Context, upgrade func(context.Context) error) func() {
+	shutdownSignals := r.shutdownSignalSet()
+	handlers := make(map[os.Signal][]func(context.Context) error)
+	for _, handler := range append(
+		[]signalHandler{{signals: r.upgradeSignals, handler: upgrade}, {signals: r.reloadSignals, handler: r.Reload}},
+		r.signalHandlers...,
+	) {
+		for _, sig := range handler.signals {
+			if !slices.Contains(shutdownSignals, sig) {
+				handlers[sig] = append(handlers[sig], handler.handler)
+			}
+		}
+	}
+	if len(handlers) == 0 {
+		return func() {}
+	}
+
+	signals := make(chan os.Signal, 1)
+	for sig := range handlers {
+		signal.Notify(signals, sig)
+	}
+
+	return func() {
+		defer signal.Stop(signals)
+
+		r.dispatchSignals(ctx, signals, handlers)
+	}
+}
+
+func (r Runner) dispatchSignals(
+	ctx context.Context,
+	signals <-chan os.Signal,
+	handlers map[os.Signal][]func(context.Context) error,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-signals:
+			slog.LogAttrs(ctx, slog.LevelInfo, "Received signal, executing signal handlers...", slog.Any("signal", sig))
+			r.lifecycle.emit(Event{Kind: EventSignal, Signal: sig})
+			for _, handler := range handlers[sig] {
+				if err := recovered(ctx, "signal handler", handler); err != nil {
+					slog.LogAttrs(ctx, slog.LevelWarn, "Signal handler failed.",
+						slog.Any("signal", sig), slog.Any("error", err),
+					)
+				}
+			}
+		}
+	}
+}
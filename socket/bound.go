@@ -0,0 +1,61 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package socket
+
+import (
+	"context"
+	"net"
+	"slices"
+	"sync"
+)
+
+// Bound reports the addresses of the listeners of a server once all of them are bound,
+// which is useful to dial the server listening on an ephemeral port like `localhost:0`.
+//
+// Bound.Wait can be used as the ready gate of the named run for the server with github.com/nil-go/nilgo.Ready,
+// so the dependent runs start after the server is accepting connections.
+//
+// The zero value is ready to use. It must not be copied after first use.
+type Bound struct {
+	once  sync.Once
+	done  chan struct{}
+	set   sync.Once
+	addrs []net.Addr
+}
+
+// Set sets the addresses of the bound listeners, and unblocks all waiting calls.
+// Only the first call takes effect.
+func (b *Bound) Set(addrs []net.Addr) {
+	b.init()
+	b.set.Do(func() {
+		b.addrs = slices.Clone(addrs)
+		close(b.done)
+	})
+}
+
+// Addrs blocks until the listeners are bound, and returns their addresses.
+// It returns the error if the context is done before that.
+func (b *Bound) Addrs(ctx context.Context) ([]net.Addr, error) {
+	b.init()
+	select {
+	case <-b.done:
+		return slices.Clone(b.addrs), nil
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
+
+// Wait blocks until the listeners are bound.
+// It returns the error if the context is done before that.
+func (b *Bound) Wait(ctx context.Context) error {
+	_, err := b.Addrs(ctx)
+
+	return err
+}
+
+func (b *Bound) init() {
+	b.once.Do(func() {
+		b.done = make(chan struct{})
+	})
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package socket_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/nil-go/nilgo/internal/assert"
+	"github.com/nil-go/nilgo/socket"
+)
+
+func TestBound(t *testing.T) {
+	t.Parallel()
+
+	var bound socket.Bound
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}
+	go bound.Set([]net.Addr{addr})
+
+	addrs, err := bound.Addrs(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []net.Addr{addr}, addrs)
+
+	bound.Set(nil) // Only the first call takes effect.
+	assert.NoError(t, bound.Wait(context.Background()))
+	addrs, err = bound.Addrs(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []net.Addr{addr}, addrs)
+}
+
+func TestBound_canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("canceled"))
+
+	var bound socket.Bound
+	assert.EqualError(t, bound.Wait(ctx), "canceled")
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package socket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Handoff passes all open listeners created by [Listen] to the command
+// through extra file descriptors, so the process started by the command
+// can inherit them with Listen on the same addresses.
+//
+// It returns the function to complete the handoff, which should be called once the process
+// started by the command is ready, or fails to start. It closes the duplicated file descriptors,
+// and keeps the unix socket files on close of the listeners if the process has taken over them,
+// while the files are still deleted on close if the process fails.
+func Handoff(cmd *exec.Cmd) (func(handedOff bool), error) {
+	listeners.mutex.Lock()
+	defer listeners.mutex.Unlock()
+
+	files := make([]*os.File, 0, len(listeners.listeners))
+	closeFiles := func() {
+		for _, file := range files {
+			_ = file.Close()
+		}
+	}
+	addresses := make([]string, 0, len(listeners.listeners))
+	var unixListeners []*net.UnixListener
+	for _, listener := range listeners.listeners {
+		filer, ok := listener.Listener.(interface{ File() (*os.File, error) })
+		if !ok {
+			continue
+		}
+		file, err := filer.File()
+		if err != nil {
+			closeFiles()
+
+			return nil, fmt.Errorf("get file of listener on %s: %w", listener.address, err)
+		}
+		if unixListener, ok := listener.Listener.(*net.UnixListener); ok {
+			unixListeners = append(unixListeners, unixListener)
+		}
+		files = append(files, file)
+		addresses = append(addresses, listener.address)
+	}
+
+	encoded, err := json.Marshal(struct {
+		Start     int      `json:"start"`
+		Addresses []string `json:"addresses"`
+	}{
+		Start:     listenFDsStart + len(cmd.ExtraFiles),
+		Addresses: addresses,
+	})
+	if err != nil {
+		closeFiles()
+
+		return nil, fmt.Errorf("encode inherited listeners: %w", err)
+	}
+	cmd.ExtraFiles = append(cmd.ExtraFiles, files...)
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = slices.DeleteFunc(cmd.Env, func(env string) bool { return strings.HasPrefix(env, inheritEnv+"=") })
+	cmd.Env = append(cmd.Env, inheritEnv+"="+string(encoded))
+
+	return func(handedOff bool) {
+		closeFiles()
+		if handedOff {
+			for _, unixListener := range unixListeners {
+				// The socket file is taken over by the new process.
+				unixListener.SetUnlinkOnClose(false)
+			}
+		}
+	}, nil
+}
+
+// listener tracks the listener created by Listen until it's closed.
+type listener struct {
+	net.Listener
+	address string
+	once    sync.Once
+}
+
+func (l *listener) Close() error {
+	l.once.Do(func() {
+		listeners.mutex.Lock()
+		defer listeners.mutex.Unlock()
+
+		listeners.listeners = slices.DeleteFunc(listeners.listeners, func(ln *listener) bool { return ln == l })
+	})
+
+	return l.Listener.Close() //nolint:wrapcheck
+}
+
+func track(address string, lns []net.Listener) []net.Listener {
+	listeners.mutex.Lock()
+	defer listeners.mutex.Unlock()
+
+	tracked := make([]net.Listener, 0, len(lns))
+	for _, ln := range lns {
+		l := &listener{Listener: ln, address: address}
+		listeners.listeners = append(listeners.listeners, l)
+		tracked = append(tracked, l)
+	}
+
+	return tracked
+}
+
+// WaitInherited blocks until all listeners inherited from the parent process by [Handoff]
+// have been listened by [Listen], which means the servers are serving on all addresses of the parent process.
+// It returns the error if the context is done before that.
+func WaitInherited(ctx context.Context) error {
+	if err := loadInherited(); err != nil {
+		return err
+	}
+
+	select {
+	case <-inherited.claimed:
+		return nil
+	default:
+	}
+	select {
+	case <-inherited.claimed:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// CloseInherited closes the file descriptors inherited from the parent process by [Handoff]
+// which have not been listened by [Listen], so the kernel stops queueing connections on them.
+func CloseInherited() error {
+	if err := loadInherited(); err != nil {
+		return err
+	}
+
+	inherited.mutex.Lock()
+	defer inherited.mutex.Unlock()
+
+	var errs []error
+	for address, fds := range inherited.fds {
+		for _, fd := range fds {
+			if file := os.NewFile(uintptr(fd), address); file != nil {
+				errs = append(errs, file.Close())
+			}
+		}
+		delete(inherited.fds, address)
+	}
+	inherited.claim()
+
+	return errors.Join(errs...)
+}
+
+// inheritedListeners returns the listeners on the given address inherited from the parent process by Handoff.
+func inheritedListeners(address string) ([]net.Listener, error) {
+	if err := loadInherited(); err != nil {
+		return nil, err
+	}
+
+	inherited.mutex.Lock()
+	defer inherited.mutex.Unlock()
+
+	fds := inherited.fds[address]
+	delete(inherited.fds, address)
+	inherited.claim()
+	lns := make([]net.Listener, 0, len(fds))
+	for _, fd := range fds {
+		ln, err := fileListener(fd, address)
+		if err != nil {
+			return nil, errors.Join(err, closeAll(lns))
+		}
+		lns = append(lns, ln)
+	}
+
+	return lns, nil
+}
+
+func loadInherited() error {
+	inherited.once.Do(func() {
+		inherited.claimed = make(chan struct{})
+		defer inherited.claim()
+
+		value, exist := os.LookupEnv(inheritEnv)
+		if !exist {
+			return
+		}
+		_ = os.Unsetenv(inheritEnv) // Do not pass to child processes.
+
+		var decoded struct {
+			Start     int      `json:"start"`
+			Addresses []string `json:"addresses"`
+		}
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			inherited.err = fmt.Errorf("decode inherited listeners: %w", err)
+
+			return
+		}
+		inherited.fds = make(map[string][]int, len(decoded.Addresses))
+		for i, address := range decoded.Addresses {
+			inherited.fds[address] = append(inherited.fds[address], decoded.Start+i)
+		}
+	})
+
+	return inherited.err
+}
+
+// claim closes the claimed channel once all inherited listeners have been claimed.
+// It must be called while holding the mutex, or in the once function.
+func (i *inheritance) claim() {
+	if len(i.fds) > 0 {
+		return
+	}
+	select {
+	case <-i.claimed:
+	default:
+		close(i.claimed)
+	}
+}
+
+func closeAll(lns []net.Listener) error {
+	var errs []error
+	for _, ln := range lns {
+		errs = append(errs, ln.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+const inheritEnv = "NILGO_INHERITED_LISTENERS"
+
+//nolint:gochecknoglobals
+var (
+	listeners struct {
+		mutex     sync.Mutex
+		listeners []*listener
+	}
+	inherited inheritance
+)
+
+type inheritance struct {
+	once    sync.Once
+	err     error
+	mutex   sync.Mutex
+	fds     map[string][]int
+	claimed chan struct{}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package socket_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/nil-go/nilgo/internal/assert"
+	"github.com/nil-go/nilgo/socket"
+)
+
+//nolint:paralleltest // It hands off all open listeners.
+func TestHandoff(t *testing.T) {
+	testcases := []struct {
+		description string
+		handedOff   bool
+	}{
+		{description: "handed off", handedOff: true},
+		{description: "failed", handedOff: false},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.sock")
+			listeners, err := socket.Listen("unix:" + path)
+			assert.NoError(t, err)
+
+			complete, err := socket.Handoff(exec.Command("true"))
+			assert.NoError(t, err)
+			complete(testcase.handedOff)
+			assert.NoError(t, listeners[0].Close())
+
+			// The socket file is only kept for the new process if it has taken over the listener.
+			_, err = os.Stat(path)
+			assert.Equal(t, testcase.handedOff, err == nil)
+		})
+	}
+}
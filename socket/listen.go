@@ -0,0 +1,193 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package socket provides listeners for servers, which supports tcp and unix socket address,
+// and inherited file descriptors from the process manager, e.g. systemd socket activation.
+package socket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Listen creates listeners on the given address, which could be one of:
+//   - tcp address like `:8080`;
+//   - unix socket address like `unix:nilgo.sock` or `unix:///tmp/nilgo.sock`,
+//     which deletes the existing socket file before listening;
+//   - inherited file descriptor like `fd:3`;
+//   - systemd socket activation like `systemd`, which listens on all sockets passed by systemd,
+//     or `systemd:name`, which only listens on the sockets named by `FileDescriptorName=`.
+//
+// The inherited file descriptors are consumed once they are listened,
+// so they can not be listened for the second time.
+//
+// If the process is started by [Handoff], it inherits the listeners on the same address
+// from the parent process instead.
+func Listen(address string) ([]net.Listener, error) {
+	lns, err := inheritedListeners(address)
+	if err != nil {
+		return nil, err
+	}
+	if len(lns) == 0 {
+		if lns, err = listen(address); err != nil {
+			return nil, err
+		}
+	}
+
+	return track(address, lns), nil
+}
+
+func listen(address string) ([]net.Listener, error) {
+	switch {
+	case strings.HasPrefix(address, "unix:"):
+		path := strings.TrimPrefix(address[5:], "//")
+		if err := os.RemoveAll(path); err != nil {
+			slog.LogAttrs(context.Background(), slog.LevelWarn, "Could not delete unix socket file.", slog.Any("error", err))
+		}
+		listener, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, fmt.Errorf("listen on %s: %w", address, err)
+		}
+
+		return []net.Listener{listener}, nil
+	case strings.HasPrefix(address, "fd:"):
+		fd, err := strconv.Atoi(address[3:])
+		if err != nil || fd < 0 {
+			return nil, fmt.Errorf("invalid file descriptor in %s: %w", address, errInvalidAddress)
+		}
+		listener, err := fileListener(fd, address)
+		if err != nil {
+			return nil, err
+		}
+
+		return []net.Listener{listener}, nil
+	case address == "systemd" || strings.HasPrefix(address, "systemd:"):
+		return systemdListeners(strings.TrimPrefix(strings.TrimPrefix(address, "systemd"), ":"))
+	default:
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return nil, fmt.Errorf("listen on %s: %w", address, err)
+		}
+
+		return []net.Listener{listener}, nil
+	}
+}
+
+// IsUnix returns whether the address is an unix socket address.
+func IsUnix(address string) bool {
+	return strings.HasPrefix(address, "unix:")
+}
+
+// systemdListeners creates listeners on the file descriptors passed by systemd socket activation,
+// following the protocol of sd_listen_fds_with_names(3).
+func systemdListeners(name string) ([]net.Listener, error) {
+	systemdMutex.Lock()
+	defer systemdMutex.Unlock()
+
+	if err := loadSystemdSockets(); err != nil {
+		return nil, err
+	}
+
+	fds := make([]int, 0, len(systemdSockets))
+	for fd, socketName := range systemdSockets {
+		if name == "" || socketName == name {
+			fds = append(fds, fd)
+		}
+	}
+	slices.Sort(fds)
+	lns := make([]net.Listener, 0, len(fds))
+	for _, fd := range fds {
+		listener, err := fileListener(fd, "systemd:"+name)
+		if err != nil {
+			return nil, errors.Join(err, closeAll(lns))
+		}
+		lns = append(lns, listener)
+	}
+	if len(lns) == 0 {
+		if name == "" {
+			return nil, fmt.Errorf("no socket passed by systemd: %w", errInvalidAddress)
+		}
+
+		return nil, fmt.Errorf("no socket named %q passed by systemd: %w", name, errInvalidAddress)
+	}
+	// The file descriptors are closed once listened, so they can not be listened for the second time.
+	for _, fd := range fds {
+		delete(systemdSockets, fd)
+	}
+
+	return lns, nil
+}
+
+// loadSystemdSockets reads the sockets passed by systemd from the environment variables,
+// and then unsets the variables, so they are not inherited by the child processes.
+func loadSystemdSockets() error {
+	pid, ok := os.LookupEnv("LISTEN_PID")
+	if !ok {
+		if systemdSockets == nil {
+			return fmt.Errorf("no socket passed by systemd for pid %d: %w", os.Getpid(), errInvalidAddress)
+		}
+
+		return nil
+	}
+	defer func() {
+		_ = os.Unsetenv("LISTEN_PID")
+		_ = os.Unsetenv("LISTEN_FDS")
+		_ = os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	if pid != strconv.Itoa(os.Getpid()) {
+		return fmt.Errorf("no socket passed by systemd for pid %d: %w", os.Getpid(), errInvalidAddress)
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return fmt.Errorf("no socket passed by systemd: %w", errInvalidAddress)
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+	systemdSockets = make(map[int]string, count)
+	for i := range count {
+		var name string
+		if i < len(names) {
+			name = names[i]
+		}
+		systemdSockets[listenFDsStart+i] = name
+	}
+
+	return nil
+}
+
+func fileListener(fd int, name string) (net.Listener, error) {
+	file := os.NewFile(uintptr(fd), name)
+	if file == nil {
+		return nil, fmt.Errorf("invalid file descriptor %d: %w", fd, errInvalidAddress)
+	}
+	defer func() {
+		_ = file.Close() // The listener holds a duplicated file descriptor.
+	}()
+
+	listener, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("listen on file descriptor %d: %w", fd, err)
+	}
+
+	return listener, nil
+}
+
+const listenFDsStart = 3 // SD_LISTEN_FDS_START
+
+//nolint:gochecknoglobals
+var (
+	// systemdSockets holds the names of the sockets passed by systemd which have not been listened,
+	// keyed by the file descriptors.
+	systemdSockets map[int]string
+	systemdMutex   sync.Mutex
+)
+
+var errInvalidAddress = errors.New("invalid address")
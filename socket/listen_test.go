@@ -0,0 +1,116 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package socket_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/nil-go/nilgo/internal/assert"
+	"github.com/nil-go/nilgo/socket"
+)
+
+func TestListen(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.MkdirTemp("", "socket")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	path := filepath.Join(dir, "test.sock")
+	assert.NoError(t, os.WriteFile(path, nil, 0o600)) // Stale socket file.
+
+	tcpListener, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = tcpListener.Close() })
+	file, err := tcpListener.(*net.TCPListener).File()
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	testcases := []struct {
+		description string
+		address     string
+		network     string
+		addr        string
+	}{
+		{
+			description: "tcp",
+			address:     "localhost:0",
+			network:     "tcp",
+		},
+		{
+			description: "unix",
+			address:     "unix:" + path,
+			network:     "unix",
+			addr:        path,
+		},
+		{
+			description: "file descriptor",
+			address:     "fd:" + strconv.Itoa(int(file.Fd())),
+			network:     "tcp",
+			addr:        tcpListener.Addr().String(),
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			listeners, err := socket.Listen(testcase.address)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(listeners))
+			defer func() { _ = listeners[0].Close() }()
+
+			assert.Equal(t, testcase.network, listeners[0].Addr().Network())
+			if testcase.addr != "" {
+				assert.Equal(t, testcase.addr, listeners[0].Addr().String())
+			}
+		})
+	}
+}
+
+func TestListen_error(t *testing.T) {
+	testcases := []struct {
+		description string
+		address     string
+		env         map[string]string
+		err         string
+	}{
+		{
+			description: "invalid file descriptor",
+			address:     "fd:abc",
+			err:         "invalid file descriptor in fd:abc: invalid address",
+		},
+		{
+			description: "systemd without sockets",
+			address:     "systemd",
+			env:         map[string]string{"LISTEN_PID": "", "LISTEN_FDS": ""},
+			err:         "no socket passed by systemd for pid " + strconv.Itoa(os.Getpid()) + ": invalid address",
+		},
+		{
+			description: "systemd without named socket",
+			address:     "systemd:http",
+			env: map[string]string{
+				"LISTEN_PID":     strconv.Itoa(os.Getpid()),
+				"LISTEN_FDS":     "1",
+				"LISTEN_FDNAMES": "grpc",
+			},
+			err: `no socket named "http" passed by systemd: invalid address`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			for key, value := range testcase.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := socket.Listen(testcase.address)
+			assert.EqualError(t, err, testcase.err)
+			// The environment variables of systemd are unset once read, so they are not inherited by child processes.
+			_, ok := os.LookupEnv("LISTEN_PID")
+			assert.Equal(t, false, ok)
+		})
+	}
+}
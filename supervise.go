@@ -0,0 +1,141 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RestartPolicy determines whether the named run restarts after it returns.
+type RestartPolicy int
+
+const (
+	// RestartNever never restarts the run, and any error shuts down the Runner.
+	RestartNever RestartPolicy = iota
+	// RestartOnFailure restarts the run only if it returns non-nil error.
+	RestartOnFailure
+	// RestartAlways restarts the run whenever it returns before the Runner stops.
+	RestartAlways
+)
+
+type supervision struct {
+	policy        RestartPolicy
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	maxRestarts   int
+	restartWindow time.Duration
+}
+
+// supervise executes the named run and restarts it according to the restart policy.
+// It returns the last error if the run restarts more than allowed times within the window,
+// so the error escalates to shut down the Runner.
+func (n namedRun) supervise(ctx context.Context) error {
+	var restarts []time.Time
+	for {
+		// Recover the panic here, so the run restarts on the panic as same as other failures.
+		err := recovered(ctx, n.name, n.run)
+		if ctx.Err() != nil ||
+			n.supervision.policy == RestartNever ||
+			n.supervision.policy == RestartOnFailure && err == nil {
+			return err
+		}
+
+		now := time.Now()
+		for len(restarts) > 0 && now.Sub(restarts[0]) > n.supervision.restartWindow {
+			restarts = restarts[1:]
+		}
+		if len(restarts) >= n.supervision.maxRestarts {
+			slog.LogAttrs(ctx, slog.LevelError, "Run has exhausted restarts, shutting down...",
+				slog.String("run", n.name),
+				slog.Int("restarts", len(restarts)),
+				slog.Duration("window", n.supervision.restartWindow),
+				slog.Any("error", err),
+			)
+			if err == nil {
+				err = errRunExited
+			}
+
+			return fmt.Errorf("exhausted %d restarts within %s: %w",
+				n.supervision.maxRestarts, n.supervision.restartWindow, err,
+			)
+		}
+
+		backoff := n.supervision.minBackoff << len(restarts)
+		if backoff > n.supervision.maxBackoff || backoff <= 0 {
+			backoff = n.supervision.maxBackoff
+		}
+		restarts = append(restarts, now)
+		slog.LogAttrs(ctx, slog.LevelWarn, "Run has exited, restarting...",
+			slog.String("run", n.name),
+			slog.Int("restarts", len(restarts)),
+			slog.Duration("backoff", backoff),
+			slog.Any("error", err),
+		)
+		if counter, e := restartCounter(); e == nil {
+			counter.Add(ctx, 1, metric.WithAttributes(attribute.String("run", n.name)))
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// restartOnTrigger executes the named run, and restarts it once any of the restart triggers fires.
+func (n namedRun) restartOnTrigger(ctx context.Context) error {
+	for {
+		runCtx, cancel := context.WithCancel(ctx)
+		var triggered atomic.Bool
+		stops := make([]func(), 0, len(n.restartTriggers))
+		for _, trigger := range n.restartTriggers {
+			stops = append(stops, trigger(func() {
+				triggered.Store(true)
+				cancel()
+			}))
+		}
+
+		err := n.run(runCtx)
+		cancel()
+		for _, stop := range stops {
+			stop()
+		}
+		if ctx.Err() != nil || !triggered.Load() {
+			return err
+		}
+		slog.LogAttrs(ctx, slog.LevelInfo, "Run is restarting on trigger.",
+			slog.String("run", n.name),
+			slog.Any("error", err),
+		)
+	}
+}
+
+const meterName = "github.com/nil-go/nilgo"
+
+// restartCounter creates the counter of restarts once, which follows the meter provider set later
+// since the global meter provider delegates the instruments to it.
+//
+//nolint:gochecknoglobals
+var restartCounter = sync.OnceValues(func() (metric.Int64Counter, error) {
+	return otel.Meter(meterName).Int64Counter("nilgo.run.restarts", //nolint:wrapcheck
+		metric.WithDescription("The number of restarts of the run."),
+		metric.WithUnit("{restart}"),
+	)
+})
+
+var errRunExited = errors.New("run exited")
@@ -0,0 +1,108 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package systemd integrates the Runner with systemd service manager.
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/nil-go/nilgo"
+)
+
+// Notify notifies systemd about the lifecycle of the Runner with sd_notify protocol,
+// so the service can be managed with `Type=notify`.
+//
+// It should be provided as a pre run, e.g. `nilgo.New(nilgo.WithPreRun(systemd.Notify))`.
+// It sends `READY=1` once all start gates have opened, `STOPPING=1` once shutdown starts,
+// and `RELOADING=1` during reloading. It also sends `WATCHDOG=1` at half of `WATCHDOG_USEC`
+// if the watchdog is enabled for the service.
+//
+// It does nothing if the environment variable `NOTIFY_SOCKET` is absent.
+func Notify(ctx context.Context) error {
+	address := os.Getenv("NOTIFY_SOCKET")
+	if address == "" {
+		return nil
+	}
+	if address[0] == '@' {
+		// Abstract socket in Linux.
+		address = "\x00" + address[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: address, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("dial systemd notify socket: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			slog.LogAttrs(ctx, slog.LevelWarn, "Fail to close systemd notify socket.", slog.Any("error", err))
+		}
+	}()
+	send := func(state string) {
+		if _, err := conn.Write([]byte(state)); err != nil {
+			slog.LogAttrs(ctx, slog.LevelWarn, "Fail to notify systemd.", slog.String("state", state), slog.Any("error", err))
+		}
+	}
+
+	if runner, ok := nilgo.FromContext(ctx); ok {
+		defer runner.Subscribe(func(event nilgo.Event) {
+			switch {
+			case event.Kind == nilgo.EventStateChanged && event.State == nilgo.StateRunning:
+				send("READY=1")
+			case event.Kind == nilgo.EventStateChanged && event.State == nilgo.StateStopping:
+				send("STOPPING=1")
+			case event.Kind == nilgo.EventReloadStarted:
+				send("RELOADING=1")
+			case event.Kind == nilgo.EventReloadFinished:
+				send("READY=1")
+			}
+		})()
+		// The runner may have been running before subscription.
+		if runner.State() == nilgo.StateRunning {
+			send("READY=1")
+		}
+	}
+
+	interval := watchdogInterval(ctx)
+	if interval <= 0 {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		send("WATCHDOG=1")
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchdogInterval returns half of `WATCHDOG_USEC` if the watchdog is enabled for this process.
+func watchdogInterval(ctx context.Context) time.Duration {
+	usec := os.Getenv("WATCHDOG_USEC")
+	if usec == "" {
+		return 0
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+
+	microseconds, err := strconv.ParseInt(usec, 10, 64)
+	if err != nil || microseconds <= 0 {
+		slog.LogAttrs(ctx, slog.LevelWarn, "Invalid WATCHDOG_USEC for systemd watchdog.", slog.String("usec", usec))
+
+		return 0
+	}
+
+	return time.Duration(microseconds) * time.Microsecond / 2 //nolint:mnd
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package systemd_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/internal/assert"
+	"github.com/nil-go/nilgo/systemd"
+)
+
+func TestNotify(t *testing.T) {
+	dir, err := os.MkdirTemp("", "systemd")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	address := filepath.Join(dir, "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: address, Net: "unixgram"})
+	assert.NoError(t, err)
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+	t.Setenv("NOTIFY_SOCKET", address)
+	t.Setenv("WATCHDOG_USEC", "20000")
+
+	var (
+		mutex    sync.Mutex
+		messages []string
+	)
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+
+		buf := make([]byte, 64)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			mutex.Lock()
+			messages = append(messages, string(buf[:n]))
+			mutex.Unlock()
+			if string(buf[:n]) == "STOPPING=1" {
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner := nilgo.New(nilgo.WithPreRun(systemd.Notify))
+	assert.NoError(t, runner.Run(ctx, func(ctx context.Context) error {
+		runner, _ := nilgo.FromContext(ctx)
+		if err := runner.Reload(ctx); err != nil {
+			return err
+		}
+		cancel()
+
+		return nil
+	}))
+	<-received
+	assert.NoError(t, conn.Close())
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, true, slices.Contains(messages, "WATCHDOG=1"))
+	messages = slices.DeleteFunc(messages, func(message string) bool { return message == "WATCHDOG=1" })
+	assert.Equal(t, []string{"READY=1", "RELOADING=1", "READY=1", "STOPPING=1"}, slices.Compact(messages))
+}
+
+func TestNotify_absent(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+
+	assert.NoError(t, systemd.Notify(context.Background()))
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nil-go/nilgo/socket"
+)
+
+// upgrade re-executes the current binary with all listeners created by socket.Listen,
+// and waits until the new process is running.
+func (r Runner) upgrade(ctx context.Context) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("get executable: %w", err)
+	}
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("create pipe for readiness: %w", err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	cmd := exec.Command(executable, os.Args[1:]...) //nolint:gosec
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.ExtraFiles = []*os.File{writer}
+	cmd.Env = slices.DeleteFunc(os.Environ(), func(env string) bool { return strings.HasPrefix(env, upgradeEnv+"=") })
+	cmd.Env = append(cmd.Env, upgradeEnv+"=3")
+	complete, err := socket.Handoff(cmd)
+	if err != nil {
+		_ = writer.Close()
+
+		return fmt.Errorf("handoff listeners: %w", err)
+	}
+	slog.LogAttrs(ctx, slog.LevelInfo, "Starting new process for upgrade...", slog.String("executable", executable))
+	err = cmd.Start()
+	_ = writer.Close()
+	if err != nil {
+		complete(false)
+
+		return fmt.Errorf("start new process: %w", err)
+	}
+
+	ready := make(chan error, 1)
+	go func() {
+		_, err := reader.Read(make([]byte, 1))
+		ready <- err
+	}()
+	timer := time.NewTimer(upgradeTimeout)
+	defer timer.Stop()
+	select {
+	case err = <-ready:
+		if err != nil {
+			err = fmt.Errorf("new process exits before running: %w", err)
+		}
+	case <-timer.C:
+		err = fmt.Errorf("new process is not running after %s: %w", upgradeTimeout, errUpgradeTimeout)
+	case <-ctx.Done():
+		err = fmt.Errorf("upgrade canceled: %w", ctx.Err())
+	}
+	// The socket files are only kept for the new process once it's running.
+	complete(err == nil)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+
+		return err
+	}
+	slog.LogAttrs(ctx, slog.LevelInfo, "New process is running.", slog.Int("pid", cmd.Process.Pid))
+
+	return cmd.Process.Release() //nolint:wrapcheck
+}
+
+// notifyUpgraded notifies the parent process that the upgraded process is running,
+// once the servers listen on all listeners inherited from the parent process.
+// So the parent process keeps serving if the servers fail to start, e.g. config error.
+// It returns the function to wait for the notification completes or gives up when the context is done.
+func notifyUpgraded(ctx context.Context) func() {
+	value, exist := os.LookupEnv(upgradeEnv)
+	if !exist {
+		return func() {}
+	}
+	_ = os.Unsetenv(upgradeEnv) // Do not pass to child processes.
+
+	fd, err := strconv.Atoi(value)
+	if err != nil {
+		slog.LogAttrs(ctx, slog.LevelWarn, "Invalid file descriptor for upgrade.", slog.String("fd", value))
+
+		return func() {}
+	}
+	file := os.NewFile(uintptr(fd), "upgrade")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			_ = file.Close()
+		}()
+
+		if err := socket.WaitInherited(ctx); err != nil {
+			slog.LogAttrs(ctx, slog.LevelWarn, "Not all inherited listeners are listened for upgrade.", slog.Any("error", err))
+			if err := socket.CloseInherited(); err != nil {
+				slog.LogAttrs(ctx, slog.LevelWarn, "Fail to close inherited listeners.", slog.Any("error", err))
+			}
+
+			return
+		}
+		if _, err := file.Write([]byte{1}); err != nil {
+			slog.LogAttrs(ctx, slog.LevelWarn, "Fail to notify parent process for upgrade.", slog.Any("error", err))
+		}
+	}()
+
+	return func() { <-done }
+}
+
+const (
+	upgradeEnv     = "NILGO_UPGRADE_FD"
+	upgradeTimeout = time.Minute
+)
+
+var errUpgradeTimeout = errors.New("upgrade timeout")
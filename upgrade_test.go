@@ -0,0 +1,112 @@
+// Copyright (c) 2024 The nilgo authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package nilgo_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/nil-go/nilgo"
+	"github.com/nil-go/nilgo/internal/assert"
+	"github.com/nil-go/nilgo/socket"
+)
+
+func TestMain(m *testing.M) {
+	// Run as the upgraded process started by TestRunner_Run_upgradeFailed, which fails before listening.
+	if os.Getenv("NILGO_TEST_UPGRADE_FAIL") != "" {
+		_ = nilgo.New().Run(context.Background(), func(context.Context) error { return errors.New("config error") })
+		os.Exit(1)
+	}
+	// Run as the upgraded process started by TestRunner_Run_upgrade.
+	if path := os.Getenv("NILGO_TEST_UPGRADE"); path != "" {
+		listeners, err := socket.Listen("localhost:0")
+		if err != nil || len(listeners) != 1 {
+			os.Exit(1)
+		}
+		if err := os.WriteFile(path, []byte(listeners[0].Addr().String()), 0o600); err != nil {
+			os.Exit(1)
+		}
+		if err := nilgo.New().Run(context.Background(), func(context.Context) error { return nil }); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestRunner_Run_upgrade(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "address")
+	t.Setenv("NILGO_TEST_UPGRADE", path)
+
+	listeners, err := socket.Listen("localhost:0")
+	assert.NoError(t, err)
+	defer func() { _ = listeners[0].Close() }()
+
+	startTime := time.Now()
+	runner := nilgo.New(nilgo.WithUpgrade(syscall.SIGUSR2))
+	assert.NoError(t, runner.Run(context.Background(), func(ctx context.Context) error {
+		if err := syscall.Kill(os.Getpid(), syscall.SIGUSR2); err != nil {
+			return err
+		}
+		<-ctx.Done()
+
+		return nil
+	}))
+	assert.Equal(t, true, time.Since(startTime) < time.Minute)
+
+	address, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, listeners[0].Addr().String(), string(address))
+}
+
+func TestRunner_Run_upgradeFailed(t *testing.T) {
+	t.Setenv("NILGO_TEST_UPGRADE_FAIL", "true")
+
+	listeners, err := socket.Listen("localhost:0")
+	assert.NoError(t, err)
+	defer func() { _ = listeners[0].Close() }()
+
+	messages := make(chan string, 10)
+	logger := slog.Default()
+	slog.SetDefault(slog.New(messageHandler{Handler: slog.NewTextHandler(os.Stderr, nil), messages: messages}))
+	defer slog.SetDefault(logger)
+
+	runner := nilgo.New(nilgo.WithUpgrade(syscall.SIGUSR2))
+	assert.NoError(t, runner.Run(context.Background(), func(context.Context) error {
+		if err := syscall.Kill(os.Getpid(), syscall.SIGUSR2); err != nil {
+			return err
+		}
+		for message := range messages {
+			if message == "Signal handler failed." {
+				break
+			}
+		}
+		// The runner keeps running since the new process exits before listening on the inherited listeners.
+		assert.Equal(t, nilgo.StateRunning, runner.State())
+
+		return nil
+	}))
+}
+
+type messageHandler struct {
+	slog.Handler
+
+	messages chan string
+}
+
+func (m messageHandler) Handle(ctx context.Context, record slog.Record) error {
+	select {
+	case m.messages <- record.Message:
+	default:
+	}
+
+	return m.Handler.Handle(ctx, record) //nolint:wrapcheck
+}